@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aoc2016/internal/day15"
+)
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+func main() {
+	input := flag.String("input", "./inputs/day-15.txt", "discs file")
+	part2 := flag.Bool("part2", false, "add the disc found below the others")
+	set := flag.String("set", "", "comma separated discs to change, as id=positions/position")
+	remove := flag.String("remove", "", "comma separated ids of discs to remove")
+	add := flag.String("add", "", "comma separated discs added below, as positions/position")
+	drop := flag.Int("drop", -1, "time when the capsule is dropped, the first time it goes through by default")
+	timeline := flag.Bool("timeline", false, "draw the discs while the capsule falls")
+	before := flag.Int("before", 0, "seconds drawn before the drop")
+	flag.Parse()
+
+	ds, err := day15.ParseFile(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *part2 {
+		ds = day15.WithDisc(ds, day15.Disc{Positions: day15.EXTRA_POSITIONS})
+	}
+	if *set != "" {
+		for _, item := range strings.Split(*set, ",") {
+			id, spec, _ := strings.Cut(item, "=")
+			n, err := strconv.Atoi(id)
+			if err != nil {
+				fail(fmt.Errorf("invalid disc id in %q", item))
+			}
+			d, err := day15.ParseDiscSpec(spec)
+			if err != nil {
+				fail(err)
+			}
+			if ds, err = day15.Reconfigure(ds, n, d); err != nil {
+				fail(err)
+			}
+		}
+	}
+	if *remove != "" {
+		var ids []int
+		for _, item := range strings.Split(*remove, ",") {
+			n, err := strconv.Atoi(item)
+			if err != nil {
+				fail(fmt.Errorf("invalid disc id %q", item))
+			}
+			if n < 1 || n > len(ds) {
+				fail(fmt.Errorf("no disc #%d of %d", n, len(ds)))
+			}
+			ids = append(ids, n)
+		}
+		// Remove from the last one, the ids of the discs above do not change
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+		for i := len(ids) - 1; i >= 0; i-- {
+			if ds, err = day15.WithoutDisc(ds, ids[i]); err != nil {
+				fail(err)
+			}
+		}
+	}
+	if *add != "" {
+		for _, spec := range strings.Split(*add, ",") {
+			d, err := day15.ParseDiscSpec(spec)
+			if err != nil {
+				fail(err)
+			}
+			ds = day15.WithDisc(ds, d)
+		}
+	}
+
+	a, err := day15.Align(ds)
+	if err != nil {
+		fmt.Println(err)
+		if *drop < 0 {
+			os.Exit(1)
+		}
+	} else {
+		fmt.Printf("First time: %v, then every %v seconds\n", a.Time, a.Period)
+	}
+	if !*timeline {
+		return
+	}
+	t := *drop
+	if t < 0 {
+		if !a.Time.IsInt64() {
+			fail(fmt.Errorf("first time %v too large to draw", a.Time))
+		}
+		t = int(a.Time.Int64())
+	}
+	if err := day15.Timeline(os.Stdout, ds, t, max(t-*before, 0), t+len(ds)+1); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"aoc2016/internal/day11"
+)
+
+func main() {
+	input := flag.String("input", "./inputs/day-11.txt", "floors file")
+	part2 := flag.Bool("part2", false, "add the extra elements found on the 1st floor")
+	floors := flag.Int("floors", 0, "number of floors, at least the highest one of the input")
+	capacity := flag.Int("capacity", day11.CAPACITY, "items carried by the elevator")
+	elevator := flag.Int("elevator", 1, "floor where the elevator starts")
+	extra := flag.String("extra", "", "comma separated elements added to the 1st floor")
+	printInput := flag.Bool("print", false, "print the building as a puzzle input instead of solving it")
+	strategy := flag.String("strategy", "", "count the moves with bfs, astar, version1, version2 or version3 instead of replaying them")
+	compare := flag.Bool("compare", false, "count the moves with every strategy and compare their statistics")
+	flag.Parse()
+
+	b, err := day11.ParseFile(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *part2 {
+		b = b.WithElements(1, day11.EXTRA_ELEMENTS...)
+	}
+	if *extra != "" {
+		b = b.WithElements(1, strings.Split(*extra, ",")...)
+	}
+	if *floors > 0 {
+		b.Floors = *floors
+	}
+	b.Capacity = *capacity
+	b.Elevator = *elevator
+	if *printInput {
+		fmt.Print(b)
+		return
+	}
+	if *compare {
+		reports, err := b.Compare()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		for _, report := range reports {
+			fmt.Println(report)
+		}
+		return
+	}
+	if *strategy != "" {
+		s, err := day11.StrategyFromString(*strategy)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		report, err := b.Search(s)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(report)
+		return
+	}
+	if err := day11.Replay(os.Stdout, b); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc2016/internal/day10"
+)
+
+func main() {
+	input := flag.String("input", "./inputs/day-10.txt", "instructions file")
+	compare := flag.String("compare", "", "chips X,Y to find the bots that compared them")
+	chip := flag.Int("chip", -1, "chip to follow from the input to its output")
+	dot := flag.String("dot", "", "file where the wiring is written as a graphviz graph")
+	validate := flag.Bool("validate", false, "report misconfigured bots and outputs")
+	flag.Parse()
+
+	is, err := day10.ParseFile(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *validate {
+		issues := day10.Validate(is)
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		if len(issues) > 0 {
+			os.Exit(1)
+		}
+	}
+	f := day10.Simulate(is)
+	if f.Err != nil {
+		fmt.Fprintln(os.Stderr, f.Err)
+	}
+	fmt.Printf("%d comparisons, %d transfers\n", len(f.Comparisons), len(f.Transfers))
+
+	if *compare != "" {
+		var x, y int
+		if _, err := fmt.Sscanf(*compare, "%d,%d", &x, &y); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -compare %q: %v\n", *compare, err)
+			os.Exit(2)
+		}
+		fmt.Printf("Chips %d and %d compared by bots %v\n", x, y, f.Comparers(x, y))
+	}
+	if *chip >= 0 {
+		fmt.Printf("Chip %d:\n", *chip)
+		for _, t := range f.ChipPath(*chip) {
+			from := "input"
+			if t.Bot != day10.INPUT {
+				from = fmt.Sprintf("bot %d", t.Bot)
+			}
+			fmt.Printf("  tick %d: %s -> %s\n", t.Tick, from, t.To)
+		}
+	}
+	if *dot != "" {
+		file, err := os.Create(*dot)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		if err := f.WriteDOT(file, is); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
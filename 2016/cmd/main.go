@@ -90,7 +90,7 @@ func runDay(day int) {
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run cmd/main.go DAY [--no-time]")
+		fmt.Println("Usage: go run cmd/main.go DAY [--no-time] [--strict] [--animate] [--cache]")
 		return
 	}
 
@@ -99,9 +99,18 @@ func main() {
 		panic(err)
 	}
 
-	if len(os.Args) == 3 {
-		if os.Args[2] == "--no-time" {
+	for _, option := range os.Args[2:] {
+		switch option {
+		case "--no-time":
 			utils.DisableTime()
+		case "--strict":
+			day09.EnableStrict()
+		case "--animate":
+			day05.EnableAnimation()
+		case "--cache":
+			day14.EnableCache()
+		default:
+			panic(fmt.Errorf("invalid option: %s", option))
 		}
 	}
 
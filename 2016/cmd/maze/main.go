@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"aoc2016/internal/day13"
+)
+
+func parsePoint(text string) (day13.Point, error) {
+	var p day13.Point
+	if _, err := fmt.Sscanf(text, "%d,%d", &p.X, &p.Y); err != nil {
+		return p, fmt.Errorf("invalid point %q: %v", text, err)
+	}
+	return p, nil
+}
+
+func wallFunc(seed int, formula, formulaFile, bitmap string) (day13.WallFunc, error) {
+	if bitmap != "" {
+		return day13.LoadBitmap(bitmap)
+	}
+	if formulaFile != "" {
+		content, err := os.ReadFile(formulaFile)
+		if err != nil {
+			return nil, err
+		}
+		formula = string(content)
+	}
+	if formula != "" {
+		return day13.ParseFormula(formula, seed)
+	}
+	return day13.NewMapGenerator(seed), nil
+}
+
+func main() {
+	input := flag.String("input", "./inputs/day-13.txt", "favorite number file")
+	seed := flag.Int("seed", -1, "favorite number, instead of the input file")
+	formula := flag.String("formula", "", "wall formula of x, y and seed, walls where it is not 0")
+	formulaFile := flag.String("formula-file", "", "file with the wall formula")
+	bitmap := flag.String("bitmap", "", "file with a static maze, # for walls")
+	width := flag.Int("width", 50, "columns of the region explored")
+	height := flag.Int("height", 50, "rows of the region explored")
+	from := flag.String("from", "1,1", "start point X,Y")
+	to := flag.String("to", "", "point X,Y to find the shortest path to")
+	algorithm := flag.String("algorithm", "astar", "shortest path search: astar, bfs or bidirectional")
+	targets := flag.String("targets", "", "points X,Y separated by ; to find the distances to in one pass")
+	steps := flag.Int("steps", -1, "count the points reachable with at most this many steps")
+	component := flag.Bool("component", false, "count the points connected to the start")
+	render := flag.Bool("render", false, "draw the region in the terminal")
+	output := flag.String("png", "", "file where the region is drawn as a png image")
+	scale := flag.Int("scale", 8, "pixels per point of the png image")
+	flag.Parse()
+
+	if *seed < 0 && *bitmap == "" {
+		var err error
+		if *seed, err = day13.ParseFile(*input); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	start, err := parsePoint(*from)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	walls, err := wallFunc(*seed, *formula, *formulaFile, *bitmap)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if b, ok := walls.(day13.Bitmap); ok {
+		*width = min(*width, b.Width())
+		*height = min(*height, b.Height())
+	}
+	m := day13.NewMaze(walls, *width, *height)
+	if !m.Open(start) {
+		fmt.Fprintf(os.Stderr, "start %v is not an open point of the region\n", start)
+		os.Exit(2)
+	}
+
+	var overlay day13.Overlay
+	if *to != "" {
+		end, err := parsePoint(*to)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		a, err := day13.AlgorithmFromString(*algorithm)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		r := m.Search(start, end, a)
+		overlay.Path = r.Path
+		if !r.Found {
+			fmt.Printf("No path from %v to %v\n", start, end)
+		} else {
+			fmt.Printf("Shortest path from %v to %v: %d steps, %d points expanded\n", start, end, r.Cost, r.Stats.Expanded)
+		}
+	}
+	if *targets != "" {
+		var points []day13.Point
+		for _, text := range strings.Split(*targets, ";") {
+			p, err := parsePoint(text)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			points = append(points, p)
+		}
+		for i, distance := range m.Distances(start, points) {
+			fmt.Printf("Distance from %v to %v: %d\n", start, points[i], distance)
+		}
+	}
+	if *steps >= 0 {
+		overlay.Explored = m.Reachable(start, *steps)
+		fmt.Printf("Points reachable from %v in at most %d steps: %d\n", start, *steps, len(overlay.Explored))
+	}
+	if *component {
+		overlay.Explored = m.Component(start)
+		fmt.Printf("Points connected to %v: %d\n", start, len(overlay.Explored))
+	}
+	if *render {
+		if err := m.Render(os.Stdout, overlay); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		if err := m.WritePNG(file, overlay, *scale); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
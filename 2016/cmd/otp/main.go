@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc2016/internal/day14"
+)
+
+func main() {
+	input := flag.String("input", "./inputs/day-14.txt", "salt file")
+	salt := flag.String("salt", "", "salt, instead of the input file")
+	keys := flag.Int("keys", 64, "number of keys to find")
+	window := flag.Int("window", day14.WINDOW, "hashes after a key where its quintuple is looked for")
+	triple := flag.Int("triple", 3, "length of the run of a key")
+	quintuple := flag.Int("quintuple", 5, "length of the run confirming a key")
+	stretch := flag.Int("stretch", 0, "times each hash is hashed again")
+	hash := flag.String("hash", "md5", "hash function, md5, sha1 or sha256")
+	limit := flag.Int("limit", 0, "number of hashes searched, 0 for the most allowed")
+	allTriples := flag.Bool("all-triples", false, "any run of a hash makes a key, not only the first one")
+	flag.Parse()
+
+	if *salt == "" {
+		s, err := day14.ParseFile(*input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		*salt = s
+	}
+	h, err := day14.HashFuncFromString(*hash)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	c := day14.Config{
+		Keys:       *keys,
+		Window:     *window,
+		Triple:     *triple,
+		Quintuple:  *quintuple,
+		Stretch:    *stretch,
+		Hash:       h,
+		Limit:      *limit,
+		AllTriples: *allTriples,
+	}
+	result, err := day14.FindKeys(*salt, c)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	for i, key := range result {
+		fmt.Printf("%3d: %d confirmed by %d\n", i+1, key.Index, key.Confirmation)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"aoc2016/internal/day08"
+)
+
+func main() {
+	input := flag.String("input", "./inputs/day-08.txt", "instructions file")
+	rows := flag.Int("rows", day08.ROWS, "screen rows")
+	cols := flag.Int("cols", day08.COLS, "screen columns")
+	animate := flag.Bool("animate", false, "play the frames in the terminal")
+	delay := flag.Duration("delay", 50*time.Millisecond, "delay between animation frames")
+	frames := flag.String("frames", "", "directory where every frame is written")
+	format := flag.String("format", "pbm", "frame format: pbm or png")
+	scale := flag.Int("scale", 8, "pixels per cell for png frames")
+	flag.Parse()
+
+	if *rows <= 0 || *cols <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid screen size: %dx%d\n", *rows, *cols)
+		os.Exit(2)
+	}
+	frameFormat, err := day08.FrameFormatFromString(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	instructions, err := day08.ParseFile(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	screen := day08.NewScreen(*rows, *cols)
+	screen.Apply(instructions)
+
+	if *animate {
+		if err := screen.Animate(os.Stdout, *delay); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		screen.Current().Display()
+	}
+	if *frames != "" {
+		if err := screen.WriteFrames(*frames, frameFormat, *scale); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	fmt.Println("Lit up:", screen.Current().CountLitUp())
+}
@@ -0,0 +1,88 @@
+package day05
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var animate bool = false
+
+// EnableAnimation shows the passwords being decrypted, only when the
+// standard output is a terminal.
+func EnableAnimation() {
+	animate = isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Delay between two frames of the animation
+const FRAME = 40 * time.Millisecond
+
+// Animation wraps a generator to keep a copy of its progress that can be
+// drawn while the generator runs.
+type Animation struct {
+	Gen
+	mu       sync.Mutex
+	progress []byte
+}
+
+func NewAnimation(g Gen) *Animation {
+	return &Animation{Gen: g, progress: g.Progress()}
+}
+
+func (a *Animation) Consume(hash string) {
+	a.Gen.Consume(hash)
+	progress := a.Gen.Progress()
+	a.mu.Lock()
+	a.progress = progress
+	a.mu.Unlock()
+}
+
+// Frame draws the password with a random digit flickering in place of each
+// character not found yet.
+func (a *Animation) Frame(r *rand.Rand) string {
+	a.mu.Lock()
+	progress := a.progress
+	a.mu.Unlock()
+	var sb strings.Builder
+	for _, c := range progress {
+		if c != 0 {
+			fmt.Fprintf(&sb, "\x1b[1;32m%c\x1b[0m", c)
+		} else {
+			fmt.Fprintf(&sb, "\x1b[2m%c\x1b[0m", "0123456789abcdef"[r.IntN(16)])
+		}
+	}
+	return sb.String()
+}
+
+// Play redraws the line label+password on w until run returns, run is given
+// the generator to fill.
+func Play(w io.Writer, label string, g Gen, run func(g Gen) string) string {
+	a := NewAnimation(g)
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	done := make(chan string)
+	go func() {
+		done <- run(a)
+	}()
+	// Hide the cursor while drawing
+	fmt.Fprint(w, "\x1b[?25l")
+	ticker := time.NewTicker(FRAME)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			fmt.Fprintf(w, "\r\x1b[2K%s%s", label, a.Frame(r))
+		case result := <-done:
+			fmt.Fprintf(w, "\r\x1b[2K%s%s\x1b[?25h\n", label, a.Frame(r))
+			return result
+		}
+	}
+}
@@ -1,14 +1,12 @@
 package day05
 
 import (
-	"crypto/md5"
-	"encoding/hex"
+	"context"
 	"fmt"
-	"io"
-	"runtime"
+	"os"
 	"strings"
-	"sync"
 
+	"aoc2016/internal/md5mine"
 	"aoc2016/internal/utils"
 )
 
@@ -21,19 +19,18 @@ func parseFile(filename string) (string, error) {
 	return content, err
 }
 
-func MD5Hash(text string) string {
-	hasher := md5.New()
-	_, err := io.WriteString(hasher, text)
-	if err != nil {
-		panic(err)
-	}
-	return hex.EncodeToString(hasher.Sum(nil))
-}
+// The digests giving a character of the password start with 5 zeros
+const ZEROS = 5
+
+// The length of the passwords
+const SIZE = 8
 
 type Gen interface {
 	Complete() bool
 	Consume(hash string)
 	Result() string
+	// The characters found so far at their position, 0 for the others
+	Progress() []byte
 }
 
 type Gen1 struct {
@@ -42,12 +39,12 @@ type Gen1 struct {
 
 func NewGen1() Gen1 {
 	var g Gen1
-	g.result = make([]byte, 0, 8)
+	g.result = make([]byte, 0, SIZE)
 	return g
 }
 
 func (g *Gen1) Complete() bool {
-	return len(g.result) >= 8
+	return len(g.result) >= SIZE
 }
 
 func (g *Gen1) Consume(hash string) {
@@ -60,6 +57,12 @@ func (g *Gen1) Result() string {
 	return string(g.result)
 }
 
+func (g *Gen1) Progress() []byte {
+	result := make([]byte, SIZE)
+	copy(result, g.result)
+	return result
+}
+
 type BytePos struct {
 	Byte rune
 	Pos  int
@@ -71,7 +74,7 @@ type Gen2 struct {
 
 func NewGen2() Gen2 {
 	var g Gen2
-	g.pos = make([]BytePos, 8)
+	g.pos = make([]BytePos, SIZE)
 	return g
 }
 
@@ -105,61 +108,69 @@ func (g *Gen2) Result() string {
 	return string(result)
 }
 
+func (g *Gen2) Progress() []byte {
+	result := make([]byte, len(g.pos))
+	for i, p := range g.pos {
+		if p.Pos != 0 {
+			result[i] = byte(p.Byte)
+		}
+	}
+	return result
+}
+
 func GeneratePasswordSequencial(g Gen, prefix string) string {
 	count := 0
 	for !g.Complete() {
-		input := fmt.Sprintf("%s%d", prefix, count)
+		hash := md5mine.Hash(prefix, count, 0)
 		count++
-		hash := MD5Hash(input)
-		g.Consume(hash)
+		if hash.LeadingZeros(ZEROS) {
+			g.Consume(hash.Hex())
+		}
 	}
 	return g.Result()
 }
 
+// GeneratePasswordParallel hashes in parallel but consumes the hits in
+// index order from a single goroutine, so it gives the same password as
+// GeneratePasswordSequencial.
 func GeneratePasswordParallel(g Gen, prefix string) string {
-	var wg sync.WaitGroup
-	workers := runtime.NumCPU()
-	gap := 128
-	start := make(chan int, workers)
-	for w := 0; w < workers; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for s := range start {
-				for i := 0; i < gap; i++ {
-					input := fmt.Sprintf("%s%d", prefix, s+i)
-					hash := MD5Hash(input)
-					g.Consume(hash)
-				}
-			}
-		}()
-	}
-	for i := 0; !g.Complete(); i += gap {
-		start <- i
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hits := md5mine.Mine(ctx, md5mine.Options{
+		Salt:   prefix,
+		Filter: func(d *md5mine.Digest) bool { return d.LeadingZeros(ZEROS) },
+	})
+	for !g.Complete() {
+		hit := <-hits
+		g.Consume(hit.Digest.Hex())
 	}
-	close(start)
-	wg.Wait()
 	return g.Result()
 }
 
 const PARALLEL = true
 
-func part1(prefix string) string {
-	g := NewGen1()
+func generatePassword(g Gen, prefix string) string {
 	if PARALLEL {
-		return GeneratePasswordParallel(&g, prefix)
+		return GeneratePasswordParallel(g, prefix)
 	} else {
-		return GeneratePasswordSequencial(&g, prefix)
+		return GeneratePasswordSequencial(g, prefix)
 	}
 }
 
+func part1(prefix string) string {
+	g := NewGen1()
+	if animate {
+		return Play(os.Stdout, "Door 1: ", &g, func(g Gen) string { return generatePassword(g, prefix) })
+	}
+	return generatePassword(&g, prefix)
+}
+
 func part2(prefix string) string {
 	g := NewGen2()
-	if PARALLEL {
-		return GeneratePasswordParallel(&g, prefix)
-	} else {
-		return GeneratePasswordSequencial(&g, prefix)
+	if animate {
+		return Play(os.Stdout, "Door 2: ", &g, func(g Gen) string { return generatePassword(g, prefix) })
 	}
+	return generatePassword(&g, prefix)
 }
 
 func Solve() {
@@ -1,9 +1,23 @@
 package day05
 
 import (
+	"slices"
+	"strings"
 	"testing"
 )
 
+// recorder keeps the hashes consumed by a generator without locking, so
+// that go test -race reports a generator fed from several goroutines.
+type recorder struct {
+	Gen
+	hashes []string
+}
+
+func (r *recorder) Consume(hash string) {
+	r.hashes = append(r.hashes, hash)
+	r.Gen.Consume(hash)
+}
+
 func TestGeneratePassword1(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -12,16 +26,23 @@ func TestGeneratePassword1(t *testing.T) {
 		{input: "abc", expected: "18f47a30"},
 	}
 
-	g := NewGen1()
 	for _, test := range tests {
-		resultSequencial := GeneratePasswordSequencial(&g, test.input)
+		g := NewGen1()
+		sequencial := recorder{Gen: &g}
+		resultSequencial := GeneratePasswordSequencial(&sequencial, test.input)
 		if resultSequencial != test.expected {
 			t.Errorf("GeneratePasswordSequencial(1, %v) = %s, want %s", test.input, resultSequencial, test.expected)
 		}
-		resultParallel := GeneratePasswordParallel(&g, test.input)
+		// A new generator, the first one is complete
+		g = NewGen1()
+		parallel := recorder{Gen: &g}
+		resultParallel := GeneratePasswordParallel(&parallel, test.input)
 		if resultParallel != resultSequencial {
 			t.Errorf("GeneratePasswordParallel(1, %v) = %s, want %s", test.input, resultParallel, test.expected)
 		}
+		if !slices.Equal(parallel.hashes, sequencial.hashes) {
+			t.Errorf("GeneratePasswordParallel(1, %v) consumed %v, want %v", test.input, parallel.hashes, sequencial.hashes)
+		}
 	}
 }
 
@@ -33,15 +54,53 @@ func TestGeneratePassword2(t *testing.T) {
 		{input: "abc", expected: "05ace8e3"},
 	}
 
-	g := NewGen2()
 	for _, test := range tests {
-		resultSequencial := GeneratePasswordSequencial(&g, test.input)
+		g := NewGen2()
+		sequencial := recorder{Gen: &g}
+		resultSequencial := GeneratePasswordSequencial(&sequencial, test.input)
 		if resultSequencial != test.expected {
 			t.Errorf("GeneratePasswordSequencial(2, %v) = %s, want %s", test.input, resultSequencial, test.expected)
 		}
-		resultParallel := GeneratePasswordParallel(&g, test.input)
+		// A new generator, the first one is complete
+		g = NewGen2()
+		parallel := recorder{Gen: &g}
+		resultParallel := GeneratePasswordParallel(&parallel, test.input)
 		if resultParallel != resultSequencial {
 			t.Errorf("GeneratePasswordParallel(2, %v) = %s, want %s", test.input, resultParallel, test.expected)
 		}
+		if !slices.Equal(parallel.hashes, sequencial.hashes) {
+			t.Errorf("GeneratePasswordParallel(2, %v) consumed %v, want %v", test.input, parallel.hashes, sequencial.hashes)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	g1 := NewGen1()
+	g1.Consume("000005xyz")
+	if result := string(g1.Progress()); result != "5\x00\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("Gen1.Progress() = %q, want %q", result, "5\x00\x00\x00\x00\x00\x00\x00")
+	}
+	g2 := NewGen2()
+	g2.Consume("000003fxyz")
+	if result := string(g2.Progress()); result != "\x00\x00\x00f\x00\x00\x00\x00" {
+		t.Errorf("Gen2.Progress() = %q, want %q", result, "\x00\x00\x00f\x00\x00\x00\x00")
+	}
+}
+
+func TestPlay(t *testing.T) {
+	var sb strings.Builder
+	g := NewGen1()
+	result := Play(&sb, "Door: ", &g, func(g Gen) string {
+		return GeneratePasswordSequencial(g, "abc")
+	})
+	if result != "18f47a30" {
+		t.Errorf("Play(abc) = %s, want %s", result, "18f47a30")
+	}
+	// The last frame shows the whole password
+	last := sb.String()[strings.LastIndex(sb.String(), "\r"):]
+	for _, c := range "18f47a30" {
+		if !strings.Contains(last, "\x1b[1;32m"+string(c)) {
+			t.Errorf("Play(abc) last frame %q misses %c", last, c)
+		}
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"aoc2016/internal/ocr"
 	"aoc2016/internal/utils"
 )
 
@@ -34,6 +35,18 @@ func NewInstructionRotateCol(col, shift int) Instruction {
 	return Instruction{Union: RotateCol{Col: col, Shift: shift}}
 }
 
+func (i Instruction) String() string {
+	switch v := i.Union.(type) {
+	case FillRect:
+		return fmt.Sprintf("rect %dx%d", v.Col, v.Row)
+	case RotateRow:
+		return fmt.Sprintf("rotate row y=%d by %d", v.Row, v.Shift)
+	case RotateCol:
+		return fmt.Sprintf("rotate column x=%d by %d", v.Col, v.Shift)
+	}
+	return "<invalid>"
+}
+
 func parseInstruction(text string) (Instruction, error) {
 	var inst Instruction
 	if rest, found := strings.CutPrefix(text, "rect "); found {
@@ -92,7 +105,7 @@ func parseContent(content string) ([]Instruction, error) {
 	return result, nil
 }
 
-func parseFile(filename string) ([]Instruction, error) {
+func ParseFile(filename string) ([]Instruction, error) {
 	content, err := utils.ReadAllFile(filename)
 	if err != nil {
 		return nil, err
@@ -100,244 +113,63 @@ func parseFile(filename string) ([]Instruction, error) {
 	return parseContent(content)
 }
 
-var letters = `
-  A
-..*..
-.*.*.
-*...*
-*****
-*...*
-*...*
-  B
-***..
-*..*.
-***..
-*..*.
-*..*.
-***..
-  C
-.**..
-*..*.
-*....
-*....
-*..*.
-.**..
-  D
-**...
-*.*..
-*..*.
-*..*.
-*.*..
-**...
-  E
-****.
-*....
-***..
-*....
-*....
-****.
-  F
-****.
-*....
-***..
-*....
-*....
-*....
-  G
-.**..
-*..*.
-*....
-*.**.
-*..*.
-.**..
-  H
-*..*.
-*..*.
-****.
-*..*.
-*..*.
-*..*.
-  I
-.***.
-..*..
-..*..
-..*..
-..*..
-.***.
-  K
-*..*.
-*.*..
-**...
-*.*..
-*.*..
-*..*.
-  J
-...*.
-...*.
-...*.
-*..*.
-*..*.
-.**..
-  L
-*....
-*....
-*....
-*....
-*....
-****.
-  M
-*...*
-**.**
-*.*.*
-*...*
-*...*
-*...*
-  N
-*...*
-**..*
-*.*.*
-*.*.*
-*.,**
-*...*
-  O
-.**..
-*..*.
-*..*.
-*..*.
-*..*.
-.**..
-  P
-***..
-*..*.
-***..
-*....
-*....
-*....
-  Q
-.**..
-*..*.
-*..*.
-*..*.
-*..*.
-.**.*
-  R
-**...
-*.*..
-*.*..
-**...
-*.*..
-*..*.
-  S
-.***.
-*....
-*....
-.**..
-...*.
-***..
-  T
-*****
-..*..
-..*..
-..*..
-..*..
-..*..
-  U
-*..*.
-*..*.
-*..*.
-*..*.
-*..*.
-.**..
-  V
-*...*
-*...*
-*...*
-*...*
-.*.*.
-..*..
-  W
-*...*
-*...*
-*...*
-*.*.*
-**.**
-*...*
-  X
-*...*
-.*.*.
-..*..
-..*..
-.*.*.
-*...*
-  Y
-*...*
-*...*
-.*.*.
-..*..
-..*..
-..*..
-  Z
-*****
-...*.
-..*..
-.*...
-*....
-*****
-`
-
+// Grid stores each row rotated by rowOffset[y] cells to the right, so the
+// logical cell (y, x) lives at the physical column (x - rowOffset[y]) mod cols.
+// Rotating a row only updates its offset and rotating a column moves each cell
+// once, whatever the shift.
 type Grid struct {
-	grid []bool
-	rows int
-	cols int
+	grid      []bool
+	rowOffset []int
+	column    []bool
+	rows      int
+	cols      int
 }
 
 func NewGrid(rows, cols int) Grid {
 	grid := make([]bool, rows*cols)
-	return Grid{rows: rows, cols: cols, grid: grid}
+	rowOffset := make([]int, rows)
+	column := make([]bool, rows)
+	return Grid{rows: rows, cols: cols, grid: grid, rowOffset: rowOffset, column: column}
 }
 
-func CompileLetters() []Grid {
-	result := make([]Grid, 0, 26)
-	letter := NewGrid(6, 5)
-	letterRow := 0
-	for _, line := range strings.Split(letters, "\n") {
-		line = strings.TrimSpace(line)
-		if len(line) == 0 {
-			continue
-		}
-		if letterRow == 0 {
-			letterRow++
-			continue
-		}
-		for x, b := range line {
-			if b == '*' {
-				letter.LitUp(letterRow-1, x)
+func (g *Grid) Rows() int {
+	return g.rows
+}
+
+func (g *Grid) Cols() int {
+	return g.cols
+}
+
+func (g *Grid) Clone() Grid {
+	r := NewGrid(g.rows, g.cols)
+	copy(r.grid, g.grid)
+	copy(r.rowOffset, g.rowOffset)
+	return r
+}
+
+func (g *Grid) Equal(other *Grid) bool {
+	if g.rows != other.rows || g.cols != other.cols {
+		return false
+	}
+	for y := 0; y < g.rows; y++ {
+		for x := 0; x < g.cols; x++ {
+			if g.Get(y, x) != other.Get(y, x) {
+				return false
 			}
 		}
-		letterRow++
-		if letterRow-1 == letter.rows {
-			result = append(result, letter)
-			letter = NewGrid(6, 5)
-			letterRow = 0
-		}
-	}
-	if len(result) != cap(result) {
-		panic("CompileLetters() failed")
 	}
-	return result
+	return true
 }
 
 func (g *Grid) String() string {
 	var b strings.Builder
-	i := 0
 	for y := 0; y < g.rows; y++ {
 		for x := 0; x < g.cols; x++ {
-			if g.grid[i] {
+			if g.Get(y, x) {
 				b.WriteByte('*')
 			} else {
 				b.WriteByte(' ')
 			}
-			i++
 		}
 		b.WriteByte('\n')
 	}
@@ -345,18 +177,7 @@ func (g *Grid) String() string {
 }
 
 func (g *Grid) Display() {
-	i := 0
-	for y := 0; y < g.rows; y++ {
-		for x := 0; x < g.cols; x++ {
-			if g.grid[i] {
-				fmt.Print("*")
-			} else {
-				fmt.Print(" ")
-			}
-			i++
-		}
-		fmt.Println()
-	}
+	fmt.Print(g.String())
 }
 
 func (g *Grid) CountLitUp() int {
@@ -369,12 +190,20 @@ func (g *Grid) CountLitUp() int {
 	return count
 }
 
+func (g *Grid) index(y, x int) int {
+	x -= g.rowOffset[y]
+	if x < 0 {
+		x += g.cols
+	}
+	return y*g.cols + x
+}
+
 func (g *Grid) Get(y, x int) bool {
-	return g.grid[y*g.cols+x]
+	return g.grid[g.index(y, x)]
 }
 
 func (g *Grid) LitUp(y, x int) {
-	g.grid[y*g.cols+x] = true
+	g.grid[g.index(y, x)] = true
 }
 
 func (g *Grid) FillRect(row, col int) {
@@ -389,74 +218,45 @@ func (g *Grid) FillRect(row, col int) {
 
 func (g *Grid) RotateRow(row, shift int) {
 	y := utils.Min(row, g.rows-1)
-	shift = shift % g.cols
-	for i := 0; i < shift; i++ {
-		last := g.grid[(y+1)*g.cols-1]
-		for x := g.cols - 2; x >= 0; x-- {
-			g.grid[y*g.cols+x+1] = g.grid[y*g.cols+x]
-		}
-		g.grid[y*g.cols+0] = last
-	}
+	g.rowOffset[y] = (g.rowOffset[y] + shift%g.cols + g.cols) % g.cols
 }
 
 func (g *Grid) RotateCol(col, shift int) {
 	x := utils.Min(col, g.cols-1)
-	shift = shift % g.rows
-	for i := 0; i < shift; i++ {
-		last := g.grid[(g.rows-1)*g.cols+x]
-		for y := g.rows - 2; y >= 0; y-- {
-			g.grid[(y+1)*g.cols+x] = g.grid[y*g.cols+x]
-		}
-		g.grid[0*g.cols+x] = last
+	shift = (shift%g.rows + g.rows) % g.rows
+	if shift == 0 {
+		return
+	}
+	for y := 0; y < g.rows; y++ {
+		g.column[y] = g.Get(y, x)
+	}
+	for y := 0; y < g.rows; y++ {
+		g.grid[g.index((y+shift)%g.rows, x)] = g.column[y]
+	}
+}
+
+func (g *Grid) Step(i Instruction) {
+	switch v := i.Union.(type) {
+	case FillRect:
+		g.FillRect(v.Row, v.Col)
+	case RotateRow:
+		g.RotateRow(v.Row, v.Shift)
+	case RotateCol:
+		g.RotateCol(v.Col, v.Shift)
 	}
 }
 
 func (g *Grid) Apply(instructions []Instruction) {
 	for _, i := range instructions {
-		switch v := i.Union.(type) {
-		case FillRect:
-			g.FillRect(v.Row, v.Col)
-		case RotateRow:
-			g.RotateRow(v.Row, v.Shift)
-		case RotateCol:
-			g.RotateCol(v.Col, v.Shift)
-		}
+		g.Step(i)
 	}
 }
 
+// Text reads the letters displayed on the grid, unknown shapes are
+// replaced by ocr.UNKNOWN.
 func (g *Grid) Text() string {
-	letters := CompileLetters()
-	stepy := letters[0].rows
-	stepx := letters[0].cols
-	var b strings.Builder
-	for gy := 0; gy <= g.rows-stepy; gy += stepy {
-		for gx := 0; gx <= g.cols-stepx; gx += stepx {
-			foundIx := -1
-			for ix, letter := range letters {
-				equals := true
-				for ly := 0; ly < letter.rows; ly++ {
-					for lx := 0; lx < letter.cols; lx++ {
-						if equals = letter.Get(ly, lx) == g.Get(gy+ly, gx+lx); !equals {
-							break
-						}
-					}
-					if !equals {
-						break
-					}
-				}
-				if equals {
-					foundIx = ix
-					break
-				}
-			}
-			if foundIx == -1 {
-				b.WriteByte(' ')
-			} else {
-				b.WriteByte(byte(int('A') + foundIx))
-			}
-		}
-	}
-	return b.String()
+	text, _ := ocr.Font6.Read(g)
+	return text
 }
 
 const (
@@ -482,7 +282,7 @@ func part2(instructions []Instruction) string {
 }
 
 func Solve() {
-	input, err := parseFile("./inputs/day-08.txt")
+	input, err := ParseFile("./inputs/day-08.txt")
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -1,6 +1,7 @@
 package day08
 
 import (
+	"math/rand/v2"
 	"reflect"
 	"testing"
 )
@@ -46,18 +47,12 @@ func TestFillRect(t *testing.T) {
 	for _, test := range tests {
 		result := NewGrid(test.input.rows, test.input.cols)
 		result.FillRect(test.row, test.col)
-		if !reflect.DeepEqual(result, test.expected) {
+		if !result.Equal(&test.expected) {
 			t.Errorf("Grid.FillRect(row=%d, col=%d) = %v, wants %v", test.row, test.col, result.String(), test.expected.String())
 		}
 	}
 }
 
-func copyGrid(g Grid) Grid {
-	r := NewGrid(g.rows, g.cols)
-	copy(r.grid, g.grid)
-	return r
-}
-
 func TestRotateRow(t *testing.T) {
 	base := NewGrid(3, 3)
 	base.LitUp(0, 0)
@@ -88,9 +83,9 @@ func TestRotateRow(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := copyGrid(base)
+		result := base.Clone()
 		result.RotateRow(test.row, test.shift)
-		if !reflect.DeepEqual(result, test.expected) {
+		if !result.Equal(&test.expected) {
 			t.Errorf("Grid.RotateRow(row=%d, shift=%d) = %v, wants %v", test.row, test.shift, result.String(), test.expected.String())
 		}
 	}
@@ -126,9 +121,9 @@ func TestRotateCol(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := copyGrid(base)
+		result := base.Clone()
 		result.RotateCol(test.col, test.shift)
-		if !reflect.DeepEqual(result, test.expected) {
+		if !result.Equal(&test.expected) {
 			t.Errorf("Grid.RotateCol(col=%d, shift=%d) = %v, wants %v", test.col, test.shift, result.String(), test.expected.String())
 		}
 	}
@@ -159,3 +154,96 @@ rotate column x=1 by 1`
 		}
 	}
 }
+
+// naiveGrid rotates the cells one position at a time, as a reference
+type naiveGrid [][]bool
+
+func (n naiveGrid) step(inst Instruction) {
+	switch v := inst.Union.(type) {
+	case FillRect:
+		for y := 0; y < v.Row; y++ {
+			for x := 0; x < v.Col; x++ {
+				n[y][x] = true
+			}
+		}
+	case RotateRow:
+		for i := 0; i < v.Shift; i++ {
+			row := n[v.Row]
+			last := row[len(row)-1]
+			copy(row[1:], row[:len(row)-1])
+			row[0] = last
+		}
+	case RotateCol:
+		for i := 0; i < v.Shift; i++ {
+			last := n[len(n)-1][v.Col]
+			for y := len(n) - 1; y > 0; y-- {
+				n[y][v.Col] = n[y-1][v.Col]
+			}
+			n[0][v.Col] = last
+		}
+	}
+}
+
+func randomInstructions(r *rand.Rand, rows, cols, count int) []Instruction {
+	result := make([]Instruction, 0, count)
+	for i := 0; i < count; i++ {
+		switch r.IntN(3) {
+		case 0:
+			result = append(result, NewInstructionFillRect(r.IntN(rows/2)+1, r.IntN(cols/2)+1))
+		case 1:
+			result = append(result, NewInstructionRotateRow(r.IntN(rows), r.IntN(2*cols)))
+		case 2:
+			result = append(result, NewInstructionRotateCol(r.IntN(cols), r.IntN(2*rows)))
+		}
+	}
+	return result
+}
+
+func TestApplyMatchesNaive(t *testing.T) {
+	r := rand.New(rand.NewPCG(8, 8))
+	rows, cols := 7, 13
+	for round := 0; round < 50; round++ {
+		grid := NewGrid(rows, cols)
+		naive := make(naiveGrid, rows)
+		for y := range naive {
+			naive[y] = make([]bool, cols)
+		}
+		for _, inst := range randomInstructions(r, rows, cols, 30) {
+			grid.Step(inst)
+			naive.step(inst)
+			for y := 0; y < rows; y++ {
+				for x := 0; x < cols; x++ {
+					if grid.Get(y, x) != naive[y][x] {
+						t.Fatalf("Grid.Get(%d, %d) after %v = %v, wants %v", y, x, inst, grid.Get(y, x), naive[y][x])
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkRotateRow(b *testing.B) {
+	grid := NewGrid(1000, 1000)
+	grid.FillRect(500, 500)
+	for i := 0; i < b.N; i++ {
+		grid.RotateRow(i%1000, 997)
+	}
+}
+
+func BenchmarkRotateCol(b *testing.B) {
+	grid := NewGrid(1000, 1000)
+	grid.FillRect(500, 500)
+	for i := 0; i < b.N; i++ {
+		grid.RotateCol(i%1000, 997)
+	}
+}
+
+func BenchmarkApply(b *testing.B) {
+	r := rand.New(rand.NewPCG(1000, 1000))
+	instructions := randomInstructions(r, 1000, 1000, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid := NewGrid(1000, 1000)
+		grid.Apply(instructions)
+	}
+}
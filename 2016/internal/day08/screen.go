@@ -0,0 +1,170 @@
+package day08
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Screen simulates a display of any size and keeps every intermediate frame,
+// Frames[0] is the blank screen and Frames[i] is the state after Steps[i-1].
+type Screen struct {
+	Frames []Grid
+	Steps  []Instruction
+}
+
+func NewScreen(rows, cols int) Screen {
+	var s Screen
+	s.Frames = []Grid{NewGrid(rows, cols)}
+	return s
+}
+
+func (s *Screen) Current() *Grid {
+	return &s.Frames[len(s.Frames)-1]
+}
+
+func (s *Screen) Apply(instructions []Instruction) {
+	for _, i := range instructions {
+		next := s.Current().Clone()
+		next.Step(i)
+		s.Frames = append(s.Frames, next)
+		s.Steps = append(s.Steps, i)
+	}
+}
+
+func (s *Screen) Caption(frame int) string {
+	if frame == 0 {
+		return fmt.Sprintf("[%d/%d] start", frame, len(s.Steps))
+	}
+	return fmt.Sprintf("[%d/%d] %s", frame, len(s.Steps), s.Steps[frame-1])
+}
+
+// Animate draws every frame in place using ANSI escape codes.
+func (s *Screen) Animate(w io.Writer, delay time.Duration) error {
+	if _, err := io.WriteString(w, "\x1b[2J"); err != nil {
+		return err
+	}
+	for i := range s.Frames {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		// Move to the top left corner and clear each line before drawing it
+		_, err := fmt.Fprintf(w, "\x1b[H\x1b[2K%s\n", s.Caption(i))
+		if err != nil {
+			return err
+		}
+		if err := s.Frames[i].WriteANSI(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Grid) WriteANSI(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for y := 0; y < g.rows; y++ {
+		bw.WriteString("\x1b[2K")
+		for x := 0; x < g.cols; x++ {
+			if g.Get(y, x) {
+				bw.WriteString("\x1b[7m \x1b[0m")
+			} else {
+				bw.WriteByte(' ')
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// WritePBM writes the grid as a plain (P1) portable bitmap.
+func (g *Grid) WritePBM(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P1\n%d %d\n", g.cols, g.rows)
+	for y := 0; y < g.rows; y++ {
+		for x := 0; x < g.cols; x++ {
+			if x > 0 {
+				bw.WriteByte(' ')
+			}
+			if g.Get(y, x) {
+				bw.WriteByte('1')
+			} else {
+				bw.WriteByte('0')
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func (g *Grid) Image(scale int) image.Image {
+	scale = max(scale, 1)
+	img := image.NewGray(image.Rect(0, 0, g.cols*scale, g.rows*scale))
+	for y := 0; y < g.rows*scale; y++ {
+		for x := 0; x < g.cols*scale; x++ {
+			if g.Get(y/scale, x/scale) {
+				img.SetGray(x, y, color.Gray{Y: 0xff})
+			}
+		}
+	}
+	return img
+}
+
+func (g *Grid) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, g.Image(scale))
+}
+
+type FrameFormat int
+
+const (
+	PBM FrameFormat = iota
+	PNG
+)
+
+func FrameFormatFromString(text string) (FrameFormat, error) {
+	switch text {
+	case "pbm":
+		return PBM, nil
+	case "png":
+		return PNG, nil
+	}
+	return PBM, fmt.Errorf("invalid frame format: %s", text)
+}
+
+// WriteFrames stores each frame in dir as frame-0000.pbm, frame-0001.pbm, ...
+func (s *Screen) WriteFrames(dir string, format FrameFormat, scale int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i := range s.Frames {
+		var err error
+		if format == PNG {
+			err = writeFrameFile(filepath.Join(dir, fmt.Sprintf("frame-%04d.png", i)), func(w io.Writer) error {
+				return s.Frames[i].WritePNG(w, scale)
+			})
+		} else {
+			err = writeFrameFile(filepath.Join(dir, fmt.Sprintf("frame-%04d.pbm", i)), s.Frames[i].WritePBM)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFrameFile(filename string, write func(w io.Writer) error) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,65 @@
+package day08
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScreenFrames(t *testing.T) {
+	content := `rect 3x2
+rotate column x=1 by 1
+rotate row y=0 by 4
+rotate column x=1 by 1`
+	expected := []string{
+		"       \n       \n       \n",
+		"***    \n***    \n       \n",
+		"* *    \n***    \n *     \n",
+		"    * *\n***    \n *     \n",
+		" *  * *\n* *    \n *     \n",
+	}
+
+	is, err := parseContent(content)
+	if err != nil {
+		t.Fatalf("parseContent(%s) failed prematurely", content)
+	}
+	screen := NewScreen(3, 7)
+	screen.Apply(is)
+	if len(screen.Frames) != len(expected) {
+		t.Fatalf("len(Screen.Frames) = %d, wants %d", len(screen.Frames), len(expected))
+	}
+	for i, frame := range screen.Frames {
+		if result := frame.String(); result != expected[i] {
+			t.Errorf("Screen.Frames[%d] = %q, wants %q", i, result, expected[i])
+		}
+	}
+}
+
+func TestInstructionString(t *testing.T) {
+	tests := []string{"rect 3x2", "rotate column x=1 by 1", "rotate row y=0 by 4"}
+
+	for _, test := range tests {
+		inst, err := parseInstruction(test)
+		if err != nil {
+			t.Errorf("parseInstruction(%v) = error '%v'", test, err)
+			continue
+		}
+		if result := inst.String(); result != test {
+			t.Errorf("Instruction.String() = %v, wants %v", result, test)
+		}
+	}
+}
+
+func TestWritePBM(t *testing.T) {
+	grid := NewGrid(2, 3)
+	grid.LitUp(0, 1)
+	grid.LitUp(1, 2)
+	expected := "P1\n3 2\n0 1 0\n0 0 1\n"
+
+	var b bytes.Buffer
+	if err := grid.WritePBM(&b); err != nil {
+		t.Fatalf("Grid.WritePBM() = error '%v'", err)
+	}
+	if b.String() != expected {
+		t.Errorf("Grid.WritePBM() = %q, wants %q", b.String(), expected)
+	}
+}
@@ -0,0 +1,206 @@
+package day09
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A token of the compressed output, period == 0 is a single literal byte,
+// otherwise text[i:i+period] repeated times.
+type choice struct {
+	period int
+	repeat int
+}
+
+type compressor struct {
+	text    string
+	version Version
+	// markerEnd[i] is where a marker-like text starting at i ends, or -1
+	markerEnd []int
+	// run[p][i] counts how many text[k] == text[k+p] hold in a row from i,
+	// only kept by V2 that reads it in any order
+	run [][]int32
+}
+
+// The longest texts compressed, V2 keeps tables of n² entries
+const (
+	MAX_COMPRESS_V1 = 1 << 16
+	MAX_COMPRESS_V2 = 1 << 10
+)
+
+func newCompressor(text string, version Version) compressor {
+	var c compressor
+	c.text = text
+	c.version = version
+	d := NewD()
+	c.markerEnd = make([]int, len(text))
+	for i := range text {
+		c.markerEnd[i] = -1
+		if m := d.Match(text[i:]); m.Found {
+			c.markerEnd[i] = i + m.End + 1
+		}
+	}
+	return c
+}
+
+func (c *compressor) buildRuns() {
+	text := c.text
+	c.run = make([][]int32, len(text)+1)
+	for p := 1; p <= len(text); p++ {
+		c.run[p] = make([]int32, len(text)-p+1)
+		for i := len(text) - p - 1; i >= 0; i-- {
+			if text[i] == text[i+p] {
+				c.run[p][i] = c.run[p][i+1] + 1
+			}
+		}
+	}
+}
+
+// A byte may be written as is when the decompressor reading up to end will
+// neither skip it nor take it as the start of a marker.
+func (c *compressor) literal(i, end int) bool {
+	if unicode.IsSpace(rune(c.text[i])) {
+		return false
+	}
+	return c.markerEnd[i] < 0 || c.markerEnd[i] > end
+}
+
+// The number of times text[i:i+p] is repeated from i, without passing end,
+// run being the matches of text[k] == text[k+p] in a row from i.
+func repeats(run int32, i, p, end int) int {
+	r := 1 + int(run)/p
+	return min(r, (end-i)/p)
+}
+
+func markerLength(length, repeat int) int {
+	return len(strconv.Itoa(length)) + len(strconv.Itoa(repeat)) + 3
+}
+
+// Compress encodes text with (AxB) markers choosing the shortest output that
+// decompresses back to text with the given version.
+//
+// V1 can encode any text, spaces and marker-like text are escaped inside a
+// section. V2 skips spaces at every level so text containing them cannot be
+// encoded. V1 takes O(n² log n) time and O(n) memory, V2 considers every
+// substring and takes O(n³ log n) time and O(n²) memory, it is meant for
+// texts of a few hundred bytes.
+func Compress(text string, version Version) (string, error) {
+	if len(text) == 0 {
+		return "", nil
+	}
+	c := newCompressor(text, version)
+	var b strings.Builder
+	switch version {
+	case V1:
+		if len(text) > MAX_COMPRESS_V1 {
+			return "", fmt.Errorf("cannot compress %d bytes with version 1, at most %d", len(text), MAX_COMPRESS_V1)
+		}
+		c.compressV1(&b)
+	case V2:
+		if len(text) > MAX_COMPRESS_V2 {
+			return "", fmt.Errorf("cannot compress %d bytes with version 2, at most %d", len(text), MAX_COMPRESS_V2)
+		}
+		if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+			return "", fmt.Errorf("cannot compress space at offset %d with version 2", i)
+		}
+		c.buildRuns()
+		c.compressV2(&b)
+	default:
+		return "", fmt.Errorf("invalid version: %d", version)
+	}
+	return b.String(), nil
+}
+
+// With V1 sections are copied as they are, only the top level needs a search.
+func (c *compressor) compressV1(b *strings.Builder) {
+	n := len(c.text)
+	cost := make([]int, n+1)
+	choices := make([]choice, n)
+	// run[p] is run[p][i] of V2, updated as i goes down
+	run := make([]int32, n+1)
+	for i := n - 1; i >= 0; i-- {
+		cost[i] = -1
+		if c.literal(i, n) {
+			cost[i] = 1 + cost[i+1]
+		}
+		for p := 1; p <= n-i; p++ {
+			if i+p < n && c.text[i] == c.text[i+p] {
+				run[p]++
+			} else {
+				run[p] = 0
+			}
+			for r := repeats(run[p], i, p, n); r >= 1; r-- {
+				total := markerLength(p, r) + p + cost[i+p*r]
+				if cost[i] < 0 || total < cost[i] {
+					cost[i] = total
+					choices[i] = choice{period: p, repeat: r}
+				}
+			}
+		}
+	}
+	for i := 0; i < n; {
+		ch := choices[i]
+		if ch.period == 0 {
+			b.WriteByte(c.text[i])
+			i++
+			continue
+		}
+		fmt.Fprintf(b, "(%dx%d)%s", ch.period, ch.repeat, c.text[i:i+ch.period])
+		i += ch.period * ch.repeat
+	}
+}
+
+// With V2 the content of each section is compressed too, so the best
+// encoding of every text[i:j] is computed, from the shortest to the longest.
+func (c *compressor) compressV2(b *strings.Builder) {
+	n := len(c.text)
+	cost := make([][]int, n+1)
+	choices := make([][]choice, n+1)
+	for i := range cost {
+		cost[i] = make([]int, n+1)
+		choices[i] = make([]choice, n+1)
+	}
+	for length := 1; length <= n; length++ {
+		for i := 0; i+length <= n; i++ {
+			j := i + length
+			best := -1
+			var bestChoice choice
+			if c.literal(i, j) {
+				best = 1 + cost[i+1][j]
+			}
+			for p := 1; p <= length; p++ {
+				for r := repeats(c.run[p][i], i, p, j); r >= 1; r-- {
+					if p == length && r == 1 {
+						// Wrapping the whole text in a section changes nothing
+						continue
+					}
+					content := cost[i][i+p]
+					total := markerLength(content, r) + content + cost[i+p*r][j]
+					if best < 0 || total < best {
+						best = total
+						bestChoice = choice{period: p, repeat: r}
+					}
+				}
+			}
+			cost[i][j] = best
+			choices[i][j] = bestChoice
+		}
+	}
+	var build func(i, j int)
+	build = func(i, j int) {
+		for i < j {
+			ch := choices[i][j]
+			if ch.period == 0 {
+				b.WriteByte(c.text[i])
+				i++
+				continue
+			}
+			fmt.Fprintf(b, "(%dx%d)", cost[i][i+ch.period], ch.repeat)
+			build(i, i+ch.period)
+			i += ch.period * ch.repeat
+		}
+	}
+	build(0, n)
+}
@@ -0,0 +1,116 @@
+package day09
+
+import (
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	tests := []struct {
+		input    string
+		version  Version
+		expected string
+	}{
+		{input: "ADVENT", version: V1, expected: "ADVENT"},
+		{input: "ABBBBBBBBBBBC", version: V1, expected: "A(1x11)BC"},
+		{input: "XYZXYZXYZ", version: V1, expected: "(3x3)XYZ"},
+		{input: "A B", version: V1, expected: "A(1x1) B"},
+		{input: "(1x3)A", version: V1, expected: "(1x1)(1x3)A"},
+		{input: "ABABABABABABABABABABABAB", version: V2, expected: "(2x12)AB"},
+		{input: strings.Repeat("AAAAAAAAAAB", 10), version: V1, expected: "(11x10)AAAAAAAAAAB"},
+		{input: strings.Repeat("AAAAAAAAAAB", 10), version: V2, expected: "(8x10)A(1x9)AB"},
+		{input: "(1x3)A", version: V2, expected: "(1x1)(1x3)A"},
+	}
+
+	for _, test := range tests {
+		result, err := Compress(test.input, test.version)
+		if err != nil {
+			t.Errorf("Compress(%q, %d) = error '%v', want %q", test.input, test.version, err, test.expected)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("Compress(%q, %d) = %q, want %q", test.input, test.version, result, test.expected)
+		}
+	}
+}
+
+func TestCompressV2Spaces(t *testing.T) {
+	if _, err := Compress("A B", V2); err == nil {
+		t.Errorf("Compress(%q, 2) = nil error, want an error", "A B")
+	}
+}
+
+func randomText(r *rand.Rand, alphabet string, n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteByte(alphabet[r.IntN(len(alphabet))])
+	}
+	return b.String()
+}
+
+func TestCompressRoundTripV1(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 1))
+	d := NewD()
+	for round := 0; round < 300; round++ {
+		text := randomText(r, "AB(x12) \n", r.IntN(60))
+		compressed, err := Compress(text, V1)
+		if err != nil {
+			t.Fatalf("Compress(%q, 1) = error '%v'", text, err)
+		}
+		if result := d.Decompress(compressed); result != text {
+			t.Fatalf("Decompress(Compress(%q, 1)) = %q", text, result)
+		}
+		if result := d.DecompressLength(compressed); result != len(text) {
+			t.Fatalf("DecompressLength(Compress(%q, 1)) = %d, want %d", text, result, len(text))
+		}
+	}
+}
+
+func TestCompressRoundTripV2(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 2))
+	d := NewD()
+	for round := 0; round < 300; round++ {
+		// Few letters produce many repetitions to nest
+		text := randomText(r, "AAB(x1)", r.IntN(40))
+		if round%3 == 0 {
+			text = strings.Repeat(text, 1+r.IntN(4))
+		}
+		compressed, err := Compress(text, V2)
+		if err != nil {
+			t.Fatalf("Compress(%q, 2) = error '%v'", text, err)
+		}
+		result, err := io.ReadAll(NewReader(compressed, V2))
+		if err != nil || string(result) != text {
+			t.Fatalf("NewReader(Compress(%q, 2), V2) = %q, error '%v'", text, result, err)
+		}
+		if result := d.DeepDecompressLength(compressed); result != len(text) {
+			t.Fatalf("DeepDecompressLength(Compress(%q, 2)) = %d, want %d", text, result, len(text))
+		}
+		if len(compressed) > len(text)+6 {
+			t.Fatalf("Compress(%q, 2) = %q, longer than needed", text, compressed)
+		}
+	}
+}
+
+func TestCompressLimits(t *testing.T) {
+	tests := []struct {
+		size    int
+		version Version
+		fails   bool
+	}{
+		{size: 2000, version: V1, fails: false},
+		{size: MAX_COMPRESS_V1 + 1, version: V1, fails: true},
+		{size: 200, version: V2, fails: false},
+		{size: MAX_COMPRESS_V2 + 1, version: V2, fails: true},
+	}
+
+	for _, test := range tests {
+		text := strings.Repeat("AB", test.size/2) + strings.Repeat("C", test.size%2)
+		_, err := Compress(text, test.version)
+		if (err != nil) != test.fails {
+			t.Errorf("Compress(%d bytes, %d) = error '%v', want failure %v", test.size, test.version, err, test.fails)
+		}
+	}
+}
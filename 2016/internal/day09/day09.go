@@ -31,14 +31,33 @@ type DMatch struct {
 	Length int
 	Repeat int
 	Found  bool
+	// Set when a number of the marker does not fit an int
+	Err error
 }
 
+// Skip and Collect stop at the end of the input when the marker claims more
+// characters than remain, use D.Validate to reject such input.
 func (m *DMatch) Skip(input string) string {
-	return input[m.End+m.Length+1:]
+	return input[m.sectionEnd(input):]
 }
 
 func (m *DMatch) Collect(input string) string {
-	return input[m.End+1 : m.End+1+m.Length]
+	return input[m.End+1 : m.sectionEnd(input)]
+}
+
+// sectionEnd compares the length with what remains before adding it, so that
+// huge lengths do not overflow.
+func (m *DMatch) sectionEnd(input string) int {
+	if m.Length > len(input)-m.End-1 {
+		return len(input)
+	}
+	return m.End + 1 + m.Length
+}
+
+// Valid tells if the marker can be applied, markers with numbers out of range
+// are read as plain text.
+func (m *DMatch) Valid() bool {
+	return m.Found && m.Err == nil
 }
 
 func (d *D) Match(input string) (m DMatch) {
@@ -46,8 +65,13 @@ func (d *D) Match(input string) (m DMatch) {
 		m.End = strings.IndexByte(input, ')')
 		cmd := input[1:m.End]
 		start, end, _ := strings.Cut(cmd, "x")
-		m.Length, _ = strconv.Atoi(start)
-		m.Repeat, _ = strconv.Atoi(end)
+		var err error
+		if m.Length, err = strconv.Atoi(start); err != nil {
+			m.Err = err
+		}
+		if m.Repeat, err = strconv.Atoi(end); err != nil {
+			m.Err = err
+		}
 	}
 	return m
 }
@@ -59,7 +83,7 @@ func (d *D) Decompress(input string) string {
 			input = input[1:]
 			continue
 		}
-		if m := d.Match(input); m.Found {
+		if m := d.Match(input); m.Valid() {
 			for i := 0; i < m.Repeat; i++ {
 				b.WriteString(m.Collect(input))
 			}
@@ -79,8 +103,9 @@ func (d *D) DecompressLength(input string) int {
 			input = input[1:]
 			continue
 		}
-		if m := d.Match(input); m.Found {
-			lengthSum += m.Repeat * m.Length
+		if m := d.Match(input); m.Valid() {
+			// Like Decompress, a truncated section repeats what remains
+			lengthSum += m.Repeat * len(m.Collect(input))
 			input = m.Skip(input)
 		} else {
 			lengthSum++
@@ -97,7 +122,7 @@ func (d *D) DeepDecompressLength(input string) int {
 			input = input[1:]
 			continue
 		}
-		if m := d.Match(input); m.Found {
+		if m := d.Match(input); m.Valid() {
 			deepLength := d.DeepDecompressLength(m.Collect(input))
 			lengthSum += m.Repeat * deepLength
 			input = m.Skip(input)
@@ -109,6 +134,15 @@ func (d *D) DeepDecompressLength(input string) int {
 	return lengthSum
 }
 
+var strict bool = false
+
+// EnableStrict makes Solve reject inputs with invalid markers instead of
+// decompressing them on a best effort basis, where markers with numbers out
+// of range are plain text.
+func EnableStrict() {
+	strict = true
+}
+
 func part1(input string) int {
 	d := NewD()
 	return d.DecompressLength(input)
@@ -127,6 +161,15 @@ func Solve() {
 	}
 
 	fmt.Println("Day 09")
+	if strict {
+		d := NewD()
+		if errs := d.Validate(input, V2); len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Println(err)
+			}
+			return
+		}
+	}
 	utils.TimeIt("Part 1:", "%d", func() any { return part1(input) })
 	utils.TimeIt("Part 2:", "%d", func() any { return part2(input) })
 }
@@ -72,3 +72,31 @@ func TestDeepDecompressLength(t *testing.T) {
 		}
 	}
 }
+
+func TestHugeMarker(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		length   int
+	}{
+		// The section stops at the end of the input
+		{input: "(9223372036854775807x2)A", expected: "AA", length: 2},
+		// Numbers out of range make plain text
+		{input: "(99999999999999999999x2)A", expected: "(99999999999999999999x2)A", length: 25},
+		{input: "(1x99999999999999999999)A", expected: "(1x99999999999999999999)A", length: 25},
+	}
+
+	d := NewD()
+	for _, test := range tests {
+		if result := d.Decompress(test.input); result != test.expected {
+			t.Errorf("Decompress(%v) = %v, want %v", test.input, result, test.expected)
+		}
+		if result := d.DecompressLength(test.input); result != test.length {
+			t.Errorf("DecompressLength(%v) = %v, want %v", test.input, result, test.length)
+		}
+		d.DeepDecompressLength(test.input)
+		if errs := d.Validate(test.input, V1); len(errs) == 0 {
+			t.Errorf("Validate(%v) = nil, want an error", test.input)
+		}
+	}
+}
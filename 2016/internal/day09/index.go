@@ -0,0 +1,135 @@
+package day09
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// A node of the marker tree, either a run of plain text or a section made of
+// children repeated a number of times.
+type node struct {
+	literal  string
+	children []node
+	// ends[k] is the decompressed length of children[:k+1]
+	ends   []int
+	repeat int
+	// Decompressed length of a single repetition
+	length int
+}
+
+// total saturates at math.MaxInt, the offsets below it are still found.
+func (n *node) total() int {
+	if n.repeat > 0 && n.length > math.MaxInt/n.repeat {
+		return math.MaxInt
+	}
+	return n.length * n.repeat
+}
+
+// Index answers queries on the version 2 decompressed text without
+// decompressing it. Finding a byte costs O(depth * log(width)) where depth is
+// the nesting of markers and width the number of items inside a section.
+type Index struct {
+	root node
+}
+
+func (d *D) parseNode(input string, repeat int) node {
+	var n node
+	n.repeat = repeat
+	appendChild := func(child node) {
+		if child.total() == 0 {
+			return
+		}
+		n.length += min(child.total(), math.MaxInt-n.length)
+		n.children = append(n.children, child)
+		n.ends = append(n.ends, n.length)
+	}
+	for len(input) > 0 {
+		if unicode.IsSpace(rune(input[0])) {
+			input = input[1:]
+			continue
+		}
+		if m := d.Match(input); m.Valid() {
+			appendChild(d.parseNode(m.Collect(input), m.Repeat))
+			input = m.Skip(input)
+			continue
+		}
+		end := strings.IndexFunc(input[1:], isLiteralEnd) + 1
+		if end == 0 {
+			end = len(input)
+		}
+		appendChild(node{literal: input[:end], length: end, repeat: 1})
+		input = input[end:]
+	}
+	return n
+}
+
+func (d *D) NewIndex(input string) Index {
+	return Index{root: d.parseNode(input, 1)}
+}
+
+func (x *Index) Len() int {
+	return x.root.total()
+}
+
+func (x *Index) At(offset int) (byte, error) {
+	if offset < 0 || offset >= x.Len() {
+		return 0, fmt.Errorf("offset %d out of range [0, %d)", offset, x.Len())
+	}
+	n := &x.root
+	for n.children != nil {
+		offset %= n.length
+		k := sort.SearchInts(n.ends, offset+1)
+		if k > 0 {
+			offset -= n.ends[k-1]
+		}
+		n = &n.children[k]
+	}
+	return n.literal[offset%n.length], nil
+}
+
+// The longest slice returned at once
+const MAX_SLICE = 1 << 30
+
+// Slice returns the decompressed bytes [from, to).
+func (x *Index) Slice(from, to int) (string, error) {
+	if from < 0 || to > x.Len() || from > to {
+		return "", fmt.Errorf("slice [%d, %d) out of range [0, %d)", from, to, x.Len())
+	}
+	if to-from > MAX_SLICE {
+		return "", fmt.Errorf("slice [%d, %d) longer than %d bytes", from, to, MAX_SLICE)
+	}
+	var b strings.Builder
+	b.Grow(to - from)
+	x.root.write(&b, from, to)
+	return b.String(), nil
+}
+
+// write emits the bytes [from, to) of the node, relative to its start.
+func (n *node) write(b *strings.Builder, from, to int) {
+	if n.children == nil {
+		b.WriteString(n.literal[from:to])
+		return
+	}
+	// The last repetition is found by division, rep*n.length may overflow
+	last := min((to-1)/n.length, n.repeat-1)
+	for rep := from / n.length; rep <= last; rep++ {
+		start := rep * n.length
+		lo := max(from-start, 0)
+		hi := min(to-start, n.length)
+		k := sort.SearchInts(n.ends, lo+1)
+		for ; k < len(n.children); k++ {
+			childStart := 0
+			if k > 0 {
+				childStart = n.ends[k-1]
+			}
+			if childStart >= hi {
+				break
+			}
+			child := &n.children[k]
+			child.write(b, max(lo-childStart, 0), min(hi-childStart, child.total()))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package day09
+
+import (
+	"io"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	tests := []string{
+		"ADVENT",
+		"X(8x2)(3x3)ABCY",
+		"(6x1)(1x3)A",
+		"(27x12)(20x12)(13x14)(7x10)(1x12)A",
+		"(25x3)(3x3)ABC(2x3)XY(5x2)PQRSTX(18x9)(3x2)TWO(5x7)SEVEN",
+		"A (3x2)B C\nD",
+	}
+
+	r := rand.New(rand.NewPCG(31, 31))
+	d := NewD()
+	for _, test := range tests {
+		expected, _ := io.ReadAll(NewReader(test, V2))
+		index := d.NewIndex(test)
+		if index.Len() != len(expected) {
+			t.Errorf("NewIndex(%q).Len() = %d, want %d", test, index.Len(), len(expected))
+			continue
+		}
+		for i := 0; i < 200; i++ {
+			offset := r.IntN(len(expected))
+			result, err := index.At(offset)
+			if err != nil || result != expected[offset] {
+				t.Errorf("NewIndex(%q).At(%d) = %q, error '%v', want %q", test, offset, result, err, expected[offset])
+			}
+			from := r.IntN(len(expected))
+			to := from + r.IntN(len(expected)-from+1)
+			slice, err := index.Slice(from, to)
+			if err != nil || slice != string(expected[from:to]) {
+				t.Errorf("NewIndex(%q).Slice(%d, %d) = %q, error '%v', want %q", test, from, to, slice, err, expected[from:to])
+			}
+		}
+	}
+}
+
+func TestIndexOutOfRange(t *testing.T) {
+	d := NewD()
+	index := d.NewIndex("(3x3)XYZ")
+	if _, err := index.At(9); err == nil {
+		t.Errorf("Index.At(9) = nil error, want an error")
+	}
+	if _, err := index.Slice(5, 10); err == nil {
+		t.Errorf("Index.Slice(5, 10) = nil error, want an error")
+	}
+}
+
+func TestIndexCompressed(t *testing.T) {
+	r := rand.New(rand.NewPCG(31, 32))
+	d := NewD()
+	for round := 0; round < 100; round++ {
+		text := strings.Repeat(randomText(r, "AABC", r.IntN(20)+1), r.IntN(5)+1)
+		compressed, err := Compress(text, V2)
+		if err != nil {
+			t.Fatalf("Compress(%q, 2) = error '%v'", text, err)
+		}
+		index := d.NewIndex(compressed)
+		for from := 0; from < len(text); from++ {
+			result, err := index.At(from)
+			if err != nil || result != text[from] {
+				t.Fatalf("NewIndex(%q).At(%d) = %q, error '%v', want %q", compressed, from, result, err, text[from])
+			}
+			to := from + r.IntN(len(text)-from+1)
+			slice, err := index.Slice(from, to)
+			if err != nil || slice != text[from:to] {
+				t.Fatalf("NewIndex(%q).Slice(%d, %d) = %q, error '%v', want %q", compressed, from, to, slice, err, text[from:to])
+			}
+		}
+	}
+}
+
+func TestIndexHugeMarker(t *testing.T) {
+	tests := []struct {
+		input  string
+		length int
+		at     int
+		byte   byte
+		from   int
+		slice  string
+	}{
+		// The section stops at the end of the input
+		{input: "(9223372036854775807x2)AB", length: 4, at: 3, byte: 'B', from: 1, slice: "BAB"},
+		// Numbers out of range make plain text
+		{input: "(99999999999999999999x2)A", length: 25, at: 24, byte: 'A', from: 22, slice: "2)A"},
+		// Lengths beyond an int saturate
+		{input: "(2x9223372036854775807)AB", length: math.MaxInt, at: 1<<62 + 1, byte: 'B', from: 1 << 62, slice: "ABA"},
+		{input: "(11x4611686018427387904)(1x3)AB", length: math.MaxInt, at: 1<<62 + 3, byte: 'B', from: 1<<62 + 2, slice: "AB"},
+	}
+
+	d := NewD()
+	for _, test := range tests {
+		index := d.NewIndex(test.input)
+		if index.Len() != test.length {
+			t.Errorf("NewIndex(%q).Len() = %d, want %d", test.input, index.Len(), test.length)
+		}
+		if result, err := index.At(test.at); err != nil || result != test.byte {
+			t.Errorf("NewIndex(%q).At(%d) = %q, error '%v', want %q", test.input, test.at, result, err, test.byte)
+		}
+		to := test.from + len(test.slice)
+		if result, err := index.Slice(test.from, to); err != nil || result != test.slice {
+			t.Errorf("NewIndex(%q).Slice(%d, %d) = %q, error '%v', want %q", test.input, test.from, to, result, err, test.slice)
+		}
+		if _, err := index.Slice(0, index.Len()); index.Len() > MAX_SLICE && err == nil {
+			t.Errorf("NewIndex(%q).Slice(0, %d) = nil error, want an error", test.input, index.Len())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package day09
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+type Version int
+
+const (
+	// Markers inside a repeated section are copied as plain text
+	V1 Version = 1
+	// Markers inside a repeated section are decompressed too
+	V2 Version = 2
+)
+
+// A section of the input being emitted, pos walks from start to end once per
+// remaining repetition.
+type frame struct {
+	start     int
+	end       int
+	pos       int
+	remaining int
+	raw       bool
+}
+
+// Reader decompresses lazily, it only keeps one frame per nesting level and
+// the markers already parsed, so the memory used does not depend on the size
+// of the output.
+type Reader struct {
+	d       D
+	input   string
+	version Version
+	stack   []frame
+	markers map[int]DMatch
+}
+
+func NewReader(input string, version Version) *Reader {
+	r := &Reader{d: NewD(), input: input, version: version, markers: make(map[int]DMatch)}
+	r.stack = append(r.stack, frame{start: 0, end: len(input), remaining: 1})
+	return r
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.stack) == 0 {
+			if n == 0 {
+				return 0, io.EOF
+			}
+			break
+		}
+		f := &r.stack[len(r.stack)-1]
+		if f.pos == f.end {
+			f.remaining--
+			if f.remaining > 0 {
+				f.pos = f.start
+			} else {
+				r.stack = r.stack[:len(r.stack)-1]
+			}
+			continue
+		}
+		if f.raw {
+			c := copy(p[n:], r.input[f.pos:f.end])
+			f.pos += c
+			n += c
+			continue
+		}
+		if unicode.IsSpace(rune(r.input[f.pos])) {
+			f.pos++
+			continue
+		}
+		if m := r.match(f); m.Found {
+			start := f.pos + m.End + 1
+			// Compare before adding, the length may be as large as an int
+			end := f.end
+			if m.Length < f.end-start {
+				end = start + m.Length
+			}
+			f.pos = end
+			if m.Repeat > 0 && start < end {
+				r.stack = append(r.stack, frame{start: start, end: end, pos: start, remaining: m.Repeat, raw: r.version == V1})
+			}
+			continue
+		}
+		// Copy plain text up to the next marker or space
+		literal := r.input[f.pos:f.end]
+		if i := strings.IndexFunc(literal[1:], isLiteralEnd); i >= 0 {
+			literal = literal[:i+1]
+		}
+		c := copy(p[n:], literal)
+		f.pos += c
+		n += c
+	}
+	return n, nil
+}
+
+func isLiteralEnd(c rune) bool {
+	return c == '(' || unicode.IsSpace(c)
+}
+
+func (r *Reader) match(f *frame) DMatch {
+	if r.input[f.pos] != '(' {
+		return DMatch{}
+	}
+	m, found := r.markers[f.pos]
+	if !found {
+		m = r.d.Match(r.input[f.pos:])
+		r.markers[f.pos] = m
+	}
+	// The marker must end inside the section being read, markers with numbers
+	// out of range are plain text
+	if !m.Valid() || f.pos+m.End >= f.end {
+		return DMatch{}
+	}
+	return m
+}
@@ -0,0 +1,100 @@
+package day09
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReaderV1(t *testing.T) {
+	tests := []string{
+		"ADVENT",
+		"A(1x5)BC",
+		"(3x3)XYZ",
+		"A(2x2)BCD(2x2)EFG",
+		"(6x1)(1x3)A",
+		"X(8x2)(3x3)ABCY",
+		"A B\n(2x2)C D",
+	}
+
+	d := NewD()
+	for _, test := range tests {
+		result, err := io.ReadAll(NewReader(test, V1))
+		if err != nil {
+			t.Errorf("io.ReadAll(NewReader(%q, V1)) = error '%v'", test, err)
+			continue
+		}
+		expected := d.Decompress(test)
+		if string(result) != expected {
+			t.Errorf("io.ReadAll(NewReader(%q, V1)) = %q, want %q", test, result, expected)
+		}
+	}
+}
+
+func TestReaderV2(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "(3x3)XYZ", expected: "XYZXYZXYZ"},
+		{input: "X(8x2)(3x3)ABCY", expected: "XABCABCABCABCABCABCY"},
+		{input: "(6x1)(1x3)A", expected: "AAA"},
+		{input: "(0x5)AB", expected: "AB"},
+	}
+
+	for _, test := range tests {
+		result, err := io.ReadAll(NewReader(test.input, V2))
+		if err != nil {
+			t.Errorf("io.ReadAll(NewReader(%q, V2)) = error '%v'", test.input, err)
+			continue
+		}
+		if string(result) != test.expected {
+			t.Errorf("io.ReadAll(NewReader(%q, V2)) = %q, want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestReaderV2Length(t *testing.T) {
+	tests := []string{
+		"(27x12)(20x12)(13x14)(7x10)(1x12)A",
+		"(25x3)(3x3)ABC(2x3)XY(5x2)PQRSTX(18x9)(3x2)TWO(5x7)SEVEN",
+	}
+
+	d := NewD()
+	for _, test := range tests {
+		var b strings.Builder
+		// A small buffer forces many partial reads
+		n, err := io.CopyBuffer(struct{ io.Writer }{&b}, NewReader(test, V2), make([]byte, 7))
+		if err != nil {
+			t.Errorf("io.Copy(NewReader(%q, V2)) = error '%v'", test, err)
+			continue
+		}
+		expected := d.DeepDecompressLength(test)
+		if int(n) != expected {
+			t.Errorf("io.Copy(NewReader(%q, V2)) = %d bytes, want %d", test, n, expected)
+		}
+		if strings.Contains(b.String(), "(") {
+			t.Errorf("io.Copy(NewReader(%q, V2)) left markers in the output", test)
+		}
+	}
+}
+
+func TestReaderHugeMarker(t *testing.T) {
+	tests := []struct {
+		input    string
+		version  Version
+		expected string
+	}{
+		{input: "(9223372036854775807x2)A", version: V1, expected: "AA"},
+		{input: "(9223372036854775807x2)A", version: V2, expected: "AA"},
+		{input: "(25x2)(9223372036854775807x2)AB", version: V2, expected: "ABABABAB"},
+		{input: "(99999999999999999999x2)A", version: V2, expected: "(99999999999999999999x2)A"},
+	}
+
+	for _, test := range tests {
+		result, err := io.ReadAll(NewReader(test.input, test.version))
+		if err != nil || string(result) != test.expected {
+			t.Errorf("io.ReadAll(NewReader(%q, %v)) = (%q, %v), want %q", test.input, test.version, result, err, test.expected)
+		}
+	}
+}
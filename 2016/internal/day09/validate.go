@@ -0,0 +1,144 @@
+package day09
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type SyntaxError struct {
+	Offset int
+	Msg    string
+	// The line around Offset with a caret below the offending byte
+	Excerpt string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("offset %d: %s\n%s", e.Offset, e.Msg, e.Excerpt)
+}
+
+const EXCERPT_RADIUS = 20
+
+func excerpt(input string, offset int) string {
+	lineStart := strings.LastIndexByte(input[:offset], '\n') + 1
+	lineEnd := len(input)
+	if i := strings.IndexByte(input[offset:], '\n'); i >= 0 {
+		lineEnd = offset + i
+	}
+	from := max(lineStart, offset-EXCERPT_RADIUS)
+	to := min(lineEnd, offset+EXCERPT_RADIUS+1)
+	prefix, suffix := "", ""
+	if from > lineStart {
+		prefix = "..."
+	}
+	if to < lineEnd {
+		suffix = "..."
+	}
+	caret := strings.Repeat(" ", len(prefix)+offset-from) + "^"
+	return prefix + input[from:to] + suffix + "\n" + caret
+}
+
+type validator struct {
+	input   string
+	version Version
+	errs    []*SyntaxError
+}
+
+func (v *validator) report(offset int, format string, args ...any) {
+	v.errs = append(v.errs, &SyntaxError{
+		Offset:  offset,
+		Msg:     fmt.Sprintf(format, args...),
+		Excerpt: excerpt(v.input, offset),
+	})
+}
+
+// Validate reports every marker the decompressor of the given version would
+// misread: malformed markers, sections longer than the remaining input and,
+// with V2, markers or sections that cross the end of their parent section.
+func (d *D) Validate(input string, version Version) []*SyntaxError {
+	v := validator{input: input, version: version}
+	v.section(0, len(input), false)
+	return v.errs
+}
+
+func (v *validator) section(start, end int, nested bool) {
+	for pos := start; pos < end; {
+		if v.input[pos] != '(' {
+			pos++
+			continue
+		}
+		length, repeat, closing, ok := v.marker(pos)
+		if !ok {
+			pos++
+			continue
+		}
+		if nested && closing >= end {
+			v.report(pos, "marker crosses the end of its parent section at offset %d", end)
+			pos = end
+			continue
+		}
+		sectionStart := closing + 1
+		sectionEnd := sectionStart + length
+		if length > len(v.input)-sectionStart {
+			v.report(pos, "truncated section: marker wants %d characters, %d remain", length, len(v.input)-sectionStart)
+			sectionEnd = len(v.input)
+		} else if nested && sectionEnd > end {
+			v.report(pos, "section ends at offset %d, after the end of its parent section at offset %d", sectionEnd, end)
+		}
+		sectionEnd = min(sectionEnd, end)
+		if v.version == V2 && repeat > 0 {
+			v.section(sectionStart, sectionEnd, true)
+		}
+		pos = sectionEnd
+	}
+}
+
+// marker parses the "(AxB)" at pos, reporting where it is malformed.
+func (v *validator) marker(pos int) (length, repeat, closing int, ok bool) {
+	i := pos + 1
+	digits := func() (int, bool) {
+		start := i
+		for i < len(v.input) && '0' <= v.input[i] && v.input[i] <= '9' {
+			i++
+		}
+		if i == start {
+			if i < len(v.input) {
+				v.report(i, "malformed marker: expected a number, found %q", v.input[i])
+			} else {
+				v.report(pos, "malformed marker: unexpected end of input")
+			}
+			return 0, false
+		}
+		n, err := strconv.Atoi(v.input[start:i])
+		if err != nil {
+			v.report(start, "malformed marker: number %s out of range", v.input[start:i])
+			return 0, false
+		}
+		return n, true
+	}
+	expect := func(c byte) bool {
+		if i < len(v.input) && v.input[i] == c {
+			i++
+			return true
+		}
+		if i < len(v.input) {
+			v.report(i, "malformed marker: expected %q, found %q", c, v.input[i])
+		} else {
+			v.report(pos, "malformed marker: unexpected end of input")
+		}
+		return false
+	}
+	if length, ok = digits(); !ok {
+		return
+	}
+	if ok = expect('x'); !ok {
+		return
+	}
+	if repeat, ok = digits(); !ok {
+		return
+	}
+	if ok = expect(')'); !ok {
+		return
+	}
+	return length, repeat, i - 1, true
+}
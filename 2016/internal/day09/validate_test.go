@@ -0,0 +1,78 @@
+package day09
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	type report struct {
+		offset int
+		msg    string
+	}
+	tests := []struct {
+		input    string
+		version  Version
+		expected []report
+	}{
+		{input: "X(8x2)(3x3)ABCY", version: V2, expected: nil},
+		{input: "A(1x5)BC(axb)", version: V1, expected: []report{
+			{offset: 9, msg: `malformed marker: expected a number, found 'a'`},
+		}},
+		{input: "(2x3", version: V1, expected: []report{
+			{offset: 0, msg: `malformed marker: unexpected end of input`},
+		}},
+		{input: "(2y3)AB", version: V1, expected: []report{
+			{offset: 2, msg: `malformed marker: expected 'x', found 'y'`},
+		}},
+		{input: "A(99999999999999999999x2)B", version: V1, expected: []report{
+			{offset: 2, msg: `malformed marker: number 99999999999999999999 out of range`},
+		}},
+		{input: "AB(10x2)XYZ", version: V1, expected: []report{
+			{offset: 2, msg: `truncated section: marker wants 10 characters, 3 remain`},
+		}},
+		{input: "(7x2)(3x3)ABCD", version: V1, expected: nil},
+		{input: "(7x2)(3x3)ABCD", version: V2, expected: []report{
+			{offset: 5, msg: `section ends at offset 13, after the end of its parent section at offset 12`},
+		}},
+		{input: "(3x2)A(1x1)B", version: V2, expected: []report{
+			{offset: 6, msg: `marker crosses the end of its parent section at offset 8`},
+		}},
+	}
+
+	d := NewD()
+	for _, test := range tests {
+		errs := d.Validate(test.input, test.version)
+		if len(errs) != len(test.expected) {
+			t.Errorf("Validate(%q, %d) = %v, want %v", test.input, test.version, errs, test.expected)
+			continue
+		}
+		for i, err := range errs {
+			if err.Offset != test.expected[i].offset || err.Msg != test.expected[i].msg {
+				t.Errorf("Validate(%q, %d)[%d] = %d %q, want %d %q", test.input, test.version, i, err.Offset, err.Msg, test.expected[i].offset, test.expected[i].msg)
+			}
+		}
+	}
+}
+
+func TestSyntaxErrorExcerpt(t *testing.T) {
+	d := NewD()
+	errs := d.Validate("ABCDEFGHIJKLMNOPQRSTUVWXYZ(10x2)ABC", V1)
+	if len(errs) != 1 {
+		t.Fatalf("Validate() = %v, want a single error", errs)
+	}
+	expected := "...GHIJKLMNOPQRSTUVWXYZ(10x2)ABC\n                       ^"
+	if errs[0].Excerpt != expected {
+		t.Errorf("SyntaxError.Excerpt = \n%s\nwant\n%s", errs[0].Excerpt, expected)
+	}
+}
+
+func TestTruncatedDoesNotPanic(t *testing.T) {
+	d := NewD()
+	input := "AB(10x2)XYZ"
+	if result := d.Decompress(input); result != "ABXYZXYZ" {
+		t.Errorf("Decompress(%q) = %q, want %q", input, result, "ABXYZXYZ")
+	}
+	if result := d.DeepDecompressLength(input); result != 8 {
+		t.Errorf("DeepDecompressLength(%q) = %d, want %d", input, result, 8)
+	}
+}
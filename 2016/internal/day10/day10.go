@@ -24,10 +24,13 @@ type Give struct {
 	Low    int
 	HighTo GiveTo
 	High   int
+	// Line of the instruction, set by ParseLines
+	Line int
 }
 type Take struct {
 	Bot   int
 	Value int
+	Line  int
 }
 type Instruction struct {
 	Union interface{}
@@ -101,26 +104,28 @@ func (p *ParseInstruction) ParseLines(content string) (Instructions, error) {
 	lines := strings.Split(content, "\n")
 	var none Instructions
 	var instructions Instructions
-	for _, line := range lines {
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
 		if len(line) == 0 {
 			continue
 		}
 		inst, err := p.Parse(line)
 		if err != nil {
-			return none, err
+			return none, fmt.Errorf("line %d: %w", i+1, err)
 		}
 		switch v := inst.Union.(type) {
 		case Give:
+			v.Line = i + 1
 			instructions.Gives = append(instructions.Gives, v)
 		case Take:
+			v.Line = i + 1
 			instructions.Takes = append(instructions.Takes, v)
 		}
 	}
 	return instructions, nil
 }
 
-func parseFile(filename string) (Instructions, error) {
+func ParseFile(filename string) (Instructions, error) {
 	content, err := utils.ReadAllFile(filename)
 	if err != nil {
 		var none Instructions
@@ -203,6 +208,10 @@ func NewProblem(gives []Give, takes []Take) Problem {
 				minBot, maxBot = minMax(v.High, minBot, maxBot)
 			}
 		}
+		if minBot > maxBot {
+			// No instruction sends a chip to a bot
+			minBot, maxBot = 0, -1
+		}
 		rangeBot := maxBot - minBot + 1
 		p.bots = make([]Bot, rangeBot)
 		p.minBot = minBot
@@ -264,7 +273,9 @@ func (p *Problem) Next() (bool, int, int, int) {
 		if p.ix < len(p.Gives) && p.Gives[p.ix].Bot == p.currBot {
 			bot := p.GetBot(p.currBot)
 			if len(bot.values) < 2 {
-				panic("unreachable len(bot.values) < 2")
+				// An earlier rule of the same bot already gave its chips away
+				p.currBot = -1
+				return p.Next()
 			}
 			low, high := bot.PopLowHigh()
 			v := p.Gives[p.ix]
@@ -294,6 +305,11 @@ func (p *Problem) Next() (bool, int, int, int) {
 	return p.Next()
 }
 
+// lastGive returns the rule applied by the last successful call to Next.
+func (p *Problem) lastGive() Give {
+	return p.Gives[p.ix-1]
+}
+
 func findBot(is Instructions, needleLow, needleHigh int) int {
 	p := NewProblem(is.Gives, is.Takes)
 	for {
@@ -328,7 +344,7 @@ func part2(is Instructions) int {
 }
 
 func Solve() {
-	input, err := parseFile("./inputs/day-10.txt")
+	input, err := ParseFile("./inputs/day-10.txt")
 	if err != nil {
 		fmt.Println(err)
 		return
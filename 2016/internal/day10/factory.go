@@ -0,0 +1,158 @@
+package day10
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+)
+
+type Destination struct {
+	To GiveTo
+	ID int
+}
+
+func (d Destination) String() string {
+	if d.To == BOT {
+		return fmt.Sprintf("bot %d", d.ID)
+	}
+	return fmt.Sprintf("output %d", d.ID)
+}
+
+func (d Destination) node() string {
+	if d.To == BOT {
+		return fmt.Sprintf("bot%d", d.ID)
+	}
+	return fmt.Sprintf("output%d", d.ID)
+}
+
+// INPUT is the bot of the transfers made by "value X goes to bot Y".
+const INPUT = -1
+
+type Transfer struct {
+	Tick int
+	Bot  int
+	Chip int
+	To   Destination
+}
+
+type Comparison struct {
+	Tick int
+	Bot  int
+	Low  int
+	High int
+}
+
+// Factory keeps the full history of a simulation, the initial values are
+// transferred at tick 0 and each comparison happens at its own tick.
+type Factory struct {
+	Transfers   []Transfer
+	Comparisons []Comparison
+	Outputs     []int
+	// Set when the simulation stopped because chips go around a cycle
+	Err error
+}
+
+func Simulate(is Instructions) Factory {
+	var f Factory
+	for _, take := range is.Takes {
+		f.Transfers = append(f.Transfers, Transfer{Tick: 0, Bot: INPUT, Chip: take.Value, To: Destination{To: BOT, ID: take.Bot}})
+	}
+	p := NewProblem(is.Gives, is.Takes)
+	for tick := 1; ; tick++ {
+		found, bot, low, high := p.Next()
+		if !found {
+			break
+		}
+		if tick > p.maxComparisons() {
+			f.Err = errCycle(tick)
+			break
+		}
+		give := p.lastGive()
+		f.Comparisons = append(f.Comparisons, Comparison{Tick: tick, Bot: bot, Low: low, High: high})
+		f.Transfers = append(f.Transfers,
+			Transfer{Tick: tick, Bot: bot, Chip: low, To: Destination{To: give.LowTo, ID: give.Low}},
+			Transfer{Tick: tick, Bot: bot, Chip: high, To: Destination{To: give.HighTo, ID: give.High}},
+		)
+	}
+	f.Outputs = p.Outputs
+	return f
+}
+
+// Comparers returns the bots that compared the chips x and y, in any order.
+func (f *Factory) Comparers(x, y int) []int {
+	low, high := min(x, y), max(x, y)
+	var result []int
+	for _, c := range f.Comparisons {
+		if c.Low == low && c.High == high && !slices.Contains(result, c.Bot) {
+			result = append(result, c.Bot)
+		}
+	}
+	return result
+}
+
+// ChipPath returns the transfers of the chip from the input to its output.
+func (f *Factory) ChipPath(chip int) []Transfer {
+	var result []Transfer
+	for _, t := range f.Transfers {
+		if t.Chip == chip {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// WriteDOT writes the wiring of the bots as a graphviz graph, each edge is
+// labeled with the chips that went through it.
+func (f *Factory) WriteDOT(w io.Writer, is Instructions) error {
+	type edge struct {
+		from string
+		to   string
+	}
+	chips := make(map[edge][]int)
+	for _, t := range f.Transfers {
+		from := fmt.Sprintf("value%d", t.Chip)
+		if t.Bot != INPUT {
+			from = fmt.Sprintf("bot%d", t.Bot)
+		}
+		e := edge{from: from, to: t.To.node()}
+		chips[e] = append(chips[e], t.Chip)
+	}
+	label := func(e edge) string {
+		if len(chips[e]) == 0 {
+			return ""
+		}
+		return fmt.Sprintf(" %v", chips[e])
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph factory {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=box];")
+	for _, take := range is.Takes {
+		from := fmt.Sprintf("value%d", take.Value)
+		e := edge{from: from, to: fmt.Sprintf("bot%d", take.Bot)}
+		fmt.Fprintf(bw, "  %s [shape=plaintext, label=\"value %d\"];\n", from, take.Value)
+		fmt.Fprintf(bw, "  %s -> %s;\n", e.from, e.to)
+	}
+	outputs := make(map[int]bool)
+	for _, give := range is.Gives {
+		from := fmt.Sprintf("bot%d", give.Bot)
+		for _, d := range []struct {
+			kind string
+			dest Destination
+		}{
+			{kind: "low", dest: Destination{To: give.LowTo, ID: give.Low}},
+			{kind: "high", dest: Destination{To: give.HighTo, ID: give.High}},
+		} {
+			if d.dest.To == OUTPUT && !outputs[d.dest.ID] {
+				outputs[d.dest.ID] = true
+				fmt.Fprintf(bw, "  %s [shape=ellipse, label=\"output %d\"];\n", d.dest.node(), d.dest.ID)
+			}
+			e := edge{from: from, to: d.dest.node()}
+			fmt.Fprintf(bw, "  %s -> %s [label=\"%s%s\"];\n", e.from, e.to, d.kind, label(e))
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
@@ -0,0 +1,74 @@
+package day10
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const example = `value 5 goes to bot 2
+bot 2 gives low to bot 1 and high to bot 0
+value 3 goes to bot 1
+bot 1 gives low to output 1 and high to bot 0
+bot 0 gives low to output 2 and high to output 0
+value 2 goes to bot 2`
+
+func TestFactoryQueries(t *testing.T) {
+	p := NewParseInstruction()
+	is, err := p.ParseLines(example)
+	if err != nil {
+		t.Fatalf("ParseInstruction.ParseLines(%v) = error '%v'", example, err)
+	}
+	f := Simulate(is)
+
+	comparers := []struct {
+		x        int
+		y        int
+		expected []int
+	}{
+		{x: 5, y: 2, expected: []int{2}},
+		{x: 2, y: 3, expected: []int{1}},
+		{x: 3, y: 5, expected: []int{0}},
+		{x: 2, y: 7, expected: nil},
+	}
+	for _, test := range comparers {
+		if result := f.Comparers(test.x, test.y); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Factory.Comparers(%d, %d) = %v, want %v", test.x, test.y, result, test.expected)
+		}
+	}
+
+	expected := []Transfer{
+		{Tick: 0, Bot: INPUT, Chip: 5, To: Destination{To: BOT, ID: 2}},
+		{Tick: 1, Bot: 2, Chip: 5, To: Destination{To: BOT, ID: 0}},
+		{Tick: 3, Bot: 0, Chip: 5, To: Destination{To: OUTPUT, ID: 0}},
+	}
+	if result := f.ChipPath(5); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Factory.ChipPath(5) = %v, want %v", result, expected)
+	}
+	if f.Outputs[0]*f.Outputs[1]*f.Outputs[2] != 30 {
+		t.Errorf("Factory.Outputs = %v, want a product of 30", f.Outputs)
+	}
+}
+
+func TestFactoryWriteDOT(t *testing.T) {
+	p := NewParseInstruction()
+	is, err := p.ParseLines(example)
+	if err != nil {
+		t.Fatalf("ParseInstruction.ParseLines(%v) = error '%v'", example, err)
+	}
+	f := Simulate(is)
+	var b strings.Builder
+	if err := f.WriteDOT(&b, is); err != nil {
+		t.Fatalf("Factory.WriteDOT() = error '%v'", err)
+	}
+	for _, expected := range []string{
+		"value5 -> bot2;",
+		`bot2 -> bot1 [label="low [2]"];`,
+		`bot0 -> output0 [label="high [5]"];`,
+		`output1 [shape=ellipse, label="output 1"];`,
+	} {
+		if !strings.Contains(b.String(), expected) {
+			t.Errorf("Factory.WriteDOT() = %s, want it to contain %s", b.String(), expected)
+		}
+	}
+}
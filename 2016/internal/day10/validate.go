@@ -0,0 +1,208 @@
+package day10
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Issue struct {
+	Msg string
+	// Lines of the offending instructions
+	Lines []int
+}
+
+func (i Issue) String() string {
+	if len(i.Lines) == 0 {
+		return i.Msg
+	}
+	lines := make([]string, len(i.Lines))
+	for k, line := range i.Lines {
+		lines[k] = fmt.Sprint(line)
+	}
+	return fmt.Sprintf("line %s: %s", strings.Join(lines, ", "), i.Msg)
+}
+
+// Run processes bots until none holds two chips. It returns the bots left
+// holding chips, empty when every chip reached an output, and an error when
+// the chips keep going around a cycle of bots.
+func (p *Problem) Run() ([]int, error) {
+	for ticks := 1; ; ticks++ {
+		if found, _, _, _ := p.Next(); !found {
+			break
+		}
+		if ticks > p.maxComparisons() {
+			return p.holding(), errCycle(ticks)
+		}
+	}
+	return p.holding(), nil
+}
+
+// Without cycles every chip goes through each bot at most once.
+func (p *Problem) maxComparisons() int {
+	return len(p.Takes) * len(p.bots)
+}
+
+func errCycle(ticks int) error {
+	return fmt.Errorf("stopped after %d comparisons, chips go around a cycle of bots", ticks)
+}
+
+func (p *Problem) holding() []int {
+	var result []int
+	for i, bot := range p.bots {
+		if len(bot.values) > 0 {
+			result = append(result, i+p.minBot)
+		}
+	}
+	return result
+}
+
+func sortedLines(lines []int) []int {
+	slices.Sort(lines)
+	return slices.Compact(lines)
+}
+
+// Validate reports bots with more than one give rule, bots that may receive
+// more than two chips, cycles in the give rules, bots left holding chips and
+// outputs that never receive a chip.
+func Validate(is Instructions) []Issue {
+	var issues []Issue
+	if len(is.Gives) == 0 && len(is.Takes) == 0 {
+		return []Issue{{Msg: "no bots"}}
+	}
+
+	gives := make(map[int][]Give)
+	incoming := make(map[int][]int)
+	for _, take := range is.Takes {
+		incoming[take.Bot] = append(incoming[take.Bot], take.Line)
+	}
+	for _, give := range is.Gives {
+		gives[give.Bot] = append(gives[give.Bot], give)
+		if give.LowTo == BOT {
+			incoming[give.Low] = append(incoming[give.Low], give.Line)
+		}
+		if give.HighTo == BOT {
+			incoming[give.High] = append(incoming[give.High], give.Line)
+		}
+	}
+	bots := make([]int, 0, len(gives))
+	for bot := range gives {
+		bots = append(bots, bot)
+	}
+	slices.Sort(bots)
+
+	for _, bot := range bots {
+		if len(gives[bot]) > 1 {
+			var lines []int
+			for _, give := range gives[bot] {
+				lines = append(lines, give.Line)
+			}
+			issues = append(issues, Issue{Msg: fmt.Sprintf("bot %d has %d give rules", bot, len(lines)), Lines: sortedLines(lines)})
+		}
+	}
+	receivers := make([]int, 0, len(incoming))
+	for bot := range incoming {
+		receivers = append(receivers, bot)
+	}
+	slices.Sort(receivers)
+	for _, bot := range receivers {
+		if len(incoming[bot]) > 2 {
+			issues = append(issues, Issue{Msg: fmt.Sprintf("bot %d may receive %d chips", bot, len(incoming[bot])), Lines: sortedLines(incoming[bot])})
+		}
+	}
+	issues = append(issues, findCycles(bots, gives)...)
+
+	p := NewProblem(slices.Clone(is.Gives), is.Takes)
+	holding, err := p.Run()
+	if err != nil {
+		// The chips that would reach outputs are unknown
+		return append(issues, Issue{Msg: err.Error()})
+	}
+	for _, bot := range holding {
+		issues = append(issues, Issue{Msg: fmt.Sprintf("bot %d is left holding %v", bot, p.GetBot(bot).values), Lines: sortedLines(incoming[bot])})
+	}
+	reached := make(map[int]bool)
+	f := Simulate(Instructions{Gives: slices.Clone(is.Gives), Takes: is.Takes})
+	for _, t := range f.Transfers {
+		if t.To.To == OUTPUT {
+			reached[t.To.ID] = true
+		}
+	}
+	unreached := make(map[int][]int)
+	for _, give := range is.Gives {
+		if give.LowTo == OUTPUT && !reached[give.Low] {
+			unreached[give.Low] = append(unreached[give.Low], give.Line)
+		}
+		if give.HighTo == OUTPUT && !reached[give.High] {
+			unreached[give.High] = append(unreached[give.High], give.Line)
+		}
+	}
+	outputs := make([]int, 0, len(unreached))
+	for output := range unreached {
+		outputs = append(outputs, output)
+	}
+	slices.Sort(outputs)
+	for _, output := range outputs {
+		issues = append(issues, Issue{Msg: fmt.Sprintf("output %d never receives a chip", output), Lines: sortedLines(unreached[output])})
+	}
+	return issues
+}
+
+// findCycles reports each cycle of bots giving chips to each other once.
+func findCycles(bots []int, gives map[int][]Give) []Issue {
+	const (
+		NEW = iota
+		VISITING
+		DONE
+	)
+	var issues []Issue
+	reported := make(map[string]bool)
+	state := make(map[int]int)
+	var path []Give
+	var visit func(bot int)
+	visit = func(bot int) {
+		state[bot] = VISITING
+		for _, give := range gives[bot] {
+			for _, next := range []struct {
+				to GiveTo
+				id int
+			}{{to: give.LowTo, id: give.Low}, {to: give.HighTo, id: give.High}} {
+				if next.to != BOT {
+					continue
+				}
+				path = append(path, give)
+				switch state[next.id] {
+				case NEW:
+					visit(next.id)
+				case VISITING:
+					start := slices.IndexFunc(path, func(g Give) bool { return g.Bot == next.id })
+					cycle := slices.Clone(path[start:])
+					names := make([]string, 0, len(cycle)+1)
+					lines := make([]int, 0, len(cycle))
+					for _, g := range cycle {
+						names = append(names, fmt.Sprint(g.Bot))
+						lines = append(lines, g.Line)
+					}
+					names = append(names, fmt.Sprint(next.id))
+					msg := "cycle between bots " + strings.Join(names, " -> ")
+					if !reported[msg] {
+						reported[msg] = true
+						issues = append(issues, Issue{Msg: msg, Lines: sortedLines(lines)})
+					}
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		state[bot] = DONE
+	}
+	for _, bot := range bots {
+		if state[bot] == NEW {
+			visit(bot)
+		}
+	}
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return cmp.Compare(a.Lines[0], b.Lines[0])
+	})
+	return issues
+}
@@ -0,0 +1,86 @@
+package day10
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: example, expected: nil},
+		{
+			input: `value 5 goes to bot 1
+value 3 goes to bot 1
+bot 1 gives low to bot 2 and high to output 0
+bot 1 gives low to output 1 and high to output 2
+bot 2 gives low to output 3 and high to output 4`,
+			expected: []string{
+				"line 3, 4: bot 1 has 2 give rules",
+				"line 3: bot 2 is left holding [3]",
+				"line 4: output 1 never receives a chip",
+				"line 4: output 2 never receives a chip",
+				"line 5: output 3 never receives a chip",
+				"line 5: output 4 never receives a chip",
+			},
+		},
+		{
+			input: `value 1 goes to bot 0
+value 2 goes to bot 0
+bot 0 gives low to bot 1 and high to bot 1
+bot 1 gives low to bot 0 and high to bot 0`,
+			expected: []string{
+				"line 1, 2, 4: bot 0 may receive 4 chips",
+				"line 3, 4: cycle between bots 0 -> 1 -> 0",
+				"stopped after 5 comparisons, chips go around a cycle of bots",
+			},
+		},
+		{input: "", expected: []string{"no bots"}},
+		{
+			input: `bot 1 gives low to output 0 and high to output 1`,
+			expected: []string{
+				"line 1: output 0 never receives a chip",
+				"line 1: output 1 never receives a chip",
+			},
+		},
+	}
+
+	p := NewParseInstruction()
+	for _, test := range tests {
+		is, err := p.ParseLines(test.input)
+		if err != nil {
+			t.Errorf("ParseInstruction.ParseLines(%v) = error '%v'", test.input, err)
+			continue
+		}
+		var result []string
+		for _, issue := range Validate(is) {
+			result = append(result, issue.String())
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Validate(%v) = %q, want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestRunStalled(t *testing.T) {
+	input := `value 5 goes to bot 1
+value 3 goes to bot 1
+bot 1 gives low to bot 2 and high to output 0
+bot 1 gives low to output 1 and high to output 2`
+
+	p := NewParseInstruction()
+	is, err := p.ParseLines(input)
+	if err != nil {
+		t.Fatalf("ParseInstruction.ParseLines(%v) = error '%v'", input, err)
+	}
+	problem := NewProblem(is.Gives, is.Takes)
+	holding, err := problem.Run()
+	if err != nil {
+		t.Fatalf("Problem.Run() = error '%v'", err)
+	}
+	if !reflect.DeepEqual(holding, []int{2}) {
+		t.Errorf("Problem.Run() = %v, want %v", holding, []int{2})
+	}
+}
@@ -0,0 +1,218 @@
+package day11
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aoc2016/internal/search"
+)
+
+// The elevator capacity of the puzzle
+const CAPACITY = 2
+
+// Building is a puzzle definition, and the state of the building while the
+// items are moved.
+type Building struct {
+	Floors   int
+	Capacity int
+	// Floor of the elevator, counted from 1
+	Elevator int
+	// Floor of each item, the generator of the i-th element at 2*i and its
+	// microchip at 2*i+1
+	Items []int
+	Names []string
+}
+
+var ORDINALS = []string{
+	"first", "second", "third", "fourth", "fifth",
+	"sixth", "seventh", "eighth", "ninth", "tenth",
+	"eleventh", "twelfth", "thirteenth", "fourteenth", "fifteenth",
+	"sixteenth", "seventeenth", "eighteenth", "nineteenth", "twentieth",
+}
+
+// ParseOrdinal reads "first" to "twentieth" and numbers like "21st".
+func ParseOrdinal(word string) (int, error) {
+	if i := slices.Index(ORDINALS, word); i >= 0 {
+		return i + 1, nil
+	}
+	if len(word) > 2 {
+		n, err := strconv.Atoi(word[:len(word)-2])
+		if err == nil && n > 0 && numericOrdinal(n) == word {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid floor ordinal: %q", word)
+}
+
+func Ordinal(n int) string {
+	if 1 <= n && n <= len(ORDINALS) {
+		return ORDINALS[n-1]
+	}
+	return numericOrdinal(n)
+}
+
+func numericOrdinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// WithElements returns a copy of the building with the generators and
+// microchips of more elements on the given floor.
+func (b Building) WithElements(floor int, names ...string) Building {
+	b.Items = slices.Clone(b.Items)
+	b.Names = slices.Clone(b.Names)
+	for _, name := range names {
+		b.Items = append(b.Items, floor, floor)
+		b.Names = append(b.Names, name)
+	}
+	return b
+}
+
+func (b Building) Validate() error {
+	if b.Floors < 2 || b.Floors > MAX_FLOORS {
+		return fmt.Errorf("invalid number of floors: %d, want 2 to %d", b.Floors, MAX_FLOORS)
+	}
+	if b.Capacity < 1 {
+		return fmt.Errorf("invalid elevator capacity: %d", b.Capacity)
+	}
+	if b.Elevator < 1 || b.Elevator > b.Floors {
+		return fmt.Errorf("elevator on floor %d of %d", b.Elevator, b.Floors)
+	}
+	if len(b.Items) != 2*len(b.Names) {
+		return fmt.Errorf("%d items for %d elements", len(b.Items), len(b.Names))
+	}
+	for i, name := range b.Names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("element %d has no name", i+1)
+		}
+		if slices.ContainsFunc(b.Names[:i], func(other string) bool { return strings.EqualFold(other, name) }) {
+			return fmt.Errorf("duplicate element: %s", name)
+		}
+	}
+	for item, floor := range b.Items {
+		if floor < 1 || floor > b.Floors {
+			return fmt.Errorf("%s on floor %d of %d", itemName(b.Names, item), floor, b.Floors)
+		}
+	}
+	if !b.Safe() {
+		return fmt.Errorf("a microchip is fried before the first move")
+	}
+	return nil
+}
+
+// Safe checks that no microchip shares a floor with a generator unless its
+// own generator is there too.
+func (b Building) Safe() bool {
+	for i := 1; i < len(b.Items); i += 2 {
+		if b.Items[i] == b.Items[i-1] {
+			continue
+		}
+		for g := 0; g < len(b.Items); g += 2 {
+			if b.Items[g] == b.Items[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (b Building) Done() bool {
+	for _, floor := range b.Items {
+		if floor != b.Floors {
+			return false
+		}
+	}
+	return true
+}
+
+// String writes the building like the puzzle input, it is parsed back by
+// ParseBuilding when the elevator starts on the 1st floor.
+func (b Building) String() string {
+	var sb strings.Builder
+	for floor := 1; floor <= b.Floors; floor++ {
+		var items []string
+		for item, f := range b.Items {
+			if f == floor {
+				items = append(items, "a "+itemName(b.Names, item))
+			}
+		}
+		fmt.Fprintf(&sb, "The %s floor contains ", Ordinal(floor))
+		switch len(items) {
+		case 0:
+			sb.WriteString("nothing relevant")
+		case 1, 2:
+			sb.WriteString(strings.Join(items, " and "))
+		default:
+			sb.WriteString(strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1])
+		}
+		sb.WriteString(".\n")
+	}
+	return sb.String()
+}
+
+func (b Building) Packer() (Packer, error) {
+	if err := b.Validate(); err != nil {
+		return Packer{}, err
+	}
+	return NewPacker(b.Floors, len(b.Names), b.Capacity)
+}
+
+// Path returns the building after each move of a shortest solution, starting
+// with b itself, or nil when the items cannot all reach the top floor.
+func (b Building) Path() ([]Building, error) {
+	p, err := b.Packer()
+	if err != nil {
+		return nil, err
+	}
+	r := search.BFS(p.Problem(p.Pack(b.Elevator, b.Items)))
+	if !r.Found {
+		return nil, nil
+	}
+	// The packed states forget which element is which, follow the moves that
+	// lead to them to find the items moved.
+	path := []Building{b}
+	l := p.layout(b.Elevator, b.Items)
+	for _, next := range r.Path[1:] {
+		p.moves(&l, func(n *layout) bool {
+			if p.pack(n) != next {
+				return true
+			}
+			l = *n
+			return false
+		})
+		curr := b
+		curr.Elevator = l.elevator + 1
+		curr.Items = make([]int, len(b.Items))
+		for i := range curr.Items {
+			curr.Items[i] = int(l.items[i]) + 1
+		}
+		path = append(path, curr)
+	}
+	return path, nil
+}
+
+// CountSteps returns the fewest moves to bring every item to the top floor,
+// -1 when it cannot be done.
+func (b Building) CountSteps() (int, error) {
+	p, err := b.Packer()
+	if err != nil {
+		return 0, err
+	}
+	r := search.BFS(p.Problem(p.Pack(b.Elevator, b.Items)))
+	if !r.Found {
+		return -1, nil
+	}
+	return r.Cost, nil
+}
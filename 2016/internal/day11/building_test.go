@@ -0,0 +1,111 @@
+package day11
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOrdinal(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected int
+		valid    bool
+	}{
+		{word: "first", expected: 1, valid: true},
+		{word: "fourth", expected: 4, valid: true},
+		{word: "twelfth", expected: 12, valid: true},
+		{word: "1st", expected: 1, valid: true},
+		{word: "22nd", expected: 22, valid: true},
+		{word: "113th", expected: 113, valid: true},
+		{word: "21th", valid: false},
+		{word: "0th", valid: false},
+		{word: "zeroth", valid: false},
+	}
+
+	for _, test := range tests {
+		result, err := ParseOrdinal(test.word)
+		if (err == nil) != test.valid || result != test.expected {
+			t.Errorf("ParseOrdinal(%s) = %v, %v; want %v", test.word, result, err, test.expected)
+		}
+	}
+}
+
+func TestBuildingString(t *testing.T) {
+	b := Building{
+		Floors:   6,
+		Capacity: CAPACITY,
+		Elevator: 1,
+		Items:    []int{1, 1, 2, 3, 5, 2},
+		Names:    []string{"thulium", "plutonium", "ruthenium"},
+	}
+	text := b.String()
+	result, err := ParseBuilding(text)
+	if err != nil {
+		t.Fatalf("ParseBuilding(%s) = error '%v'", text, err)
+	}
+	if !reflect.DeepEqual(result, b) {
+		t.Errorf("ParseBuilding(%s) = %v, want %v", text, result, b)
+	}
+}
+
+func TestCountSteps(t *testing.T) {
+	example, err := ParseBuilding(example)
+	if err != nil {
+		t.Fatalf("ParseBuilding() = error '%v'", err)
+	}
+	higher := example
+	higher.Elevator = 2
+	roof := example
+	roof.Floors = 5
+	capacity3 := example
+	capacity3.Capacity = 3
+	tests := []struct {
+		name     string
+		building Building
+		expected int
+	}{
+		{name: "example", building: example, expected: 11},
+		{name: "capacity 1", building: Building{Floors: 4, Capacity: 1, Elevator: 1, Items: []int{1, 1}, Names: []string{"a"}}, expected: -1},
+		{name: "capacity 3", building: capacity3, expected: 9},
+		{name: "elevator on the 2nd floor", building: higher, expected: 18},
+		{name: "5 floors", building: roof, expected: 16},
+		{name: "extra elements", building: example.WithElements(2, EXTRA_ELEMENTS...), expected: 27},
+	}
+	for _, test := range tests {
+		result, err := test.building.CountSteps()
+		if err != nil {
+			t.Errorf("CountSteps(%s) = error '%v'", test.name, err)
+		} else if result != test.expected {
+			t.Errorf("CountSteps(%s) = %d, want %d", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	example, err := ParseBuilding(example)
+	if err != nil {
+		t.Fatalf("ParseBuilding() = error '%v'", err)
+	}
+	tests := []struct {
+		name  string
+		patch func(b *Building)
+	}{
+		{name: "1 floor", patch: func(b *Building) { b.Floors = 1 }},
+		{name: "too many floors", patch: func(b *Building) { b.Floors = MAX_FLOORS + 1 }},
+		{name: "no capacity", patch: func(b *Building) { b.Capacity = 0 }},
+		{name: "no elevator", patch: func(b *Building) { b.Elevator = 5 }},
+		{name: "item above the top", patch: func(b *Building) { b.Floors = 2 }},
+		{name: "duplicate element", patch: func(b *Building) { *b = b.WithElements(4, "lithium") }},
+		{name: "duplicate element in another case", patch: func(b *Building) { *b = b.WithElements(4, "Lithium") }},
+		{name: "empty element", patch: func(b *Building) { *b = b.WithElements(4, "") }},
+		{name: "blank element", patch: func(b *Building) { *b = b.WithElements(4, " ") }},
+		{name: "fried microchip", patch: func(b *Building) { b.Items[0] = 1 }},
+	}
+	for _, test := range tests {
+		b := example.WithElements(1)
+		test.patch(&b)
+		if _, err := b.CountSteps(); err == nil {
+			t.Errorf("CountSteps(%s) = no error", test.name)
+		}
+	}
+}
@@ -1,23 +1,24 @@
 package day11
 
 import (
-	"container/heap"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"aoc2016/internal/search"
 	"aoc2016/internal/utils"
 )
 
 // Based on https://github.com/Kezzryn/Advent-of-Code/blob/main/2016/Day%2011/RTFElevator.cs
 
-func parseContent(content string) ([]int, error) {
+// ParseBuilding reads the floor of each item, the building has as many floors
+// as the highest one described and the elevator starts on the 1st floor.
+func ParseBuilding(content string) (Building, error) {
+	b := Building{Capacity: CAPACITY, Elevator: FLOOR_BOTTOM}
 	names := make(map[string]int)
-	floors := map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4}
-	reFloor := regexp.MustCompile(`The ([a-z]+) floor contains`)
+	reFloor := regexp.MustCompile(`The ([a-z0-9]+) floor contains`)
 	reSep := regexp.MustCompile(`( and a| a|, and a|, a)`)
-	result := make([]int, 0)
 	lines := strings.Split(content, "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -25,13 +26,14 @@ func parseContent(content string) ([]int, error) {
 			continue
 		}
 		if !reFloor.MatchString(line) {
-			return nil, fmt.Errorf("invalid floor format")
+			return b, fmt.Errorf("invalid floor format")
 		}
 		matches := reFloor.FindStringSubmatch(line)
-		floor, ok := floors[matches[1]]
-		if !ok {
-			return nil, fmt.Errorf("invalid floor ordinal format")
+		floor, err := ParseOrdinal(matches[1])
+		if err != nil {
+			return b, err
 		}
+		b.Floors = max(b.Floors, floor)
 		line = line[len(matches[0]):]
 		parts := reSep.Split(line, -1)
 		for i, part := range parts {
@@ -41,38 +43,45 @@ func parseContent(content string) ([]int, error) {
 			if name, found := strings.CutSuffix(part, " generator"); found {
 				if _, exists := names[name]; !exists {
 					names[name] = len(names) * 2
-					result = append(result, 0, 0)
+					b.Names = append(b.Names, name)
+					b.Items = append(b.Items, 0, 0)
 				}
 			}
 			if name, found := strings.CutSuffix(part, "-compatible microchip"); found {
 				if _, exists := names[name]; !exists {
 					names[name] = len(names) * 2
-					result = append(result, 0, 0)
+					b.Names = append(b.Names, name)
+					b.Items = append(b.Items, 0, 0)
 				}
 			}
 		}
 		for _, part := range parts {
 			if name, found := strings.CutSuffix(part, " generator"); found {
 				ix := names[name]
-				result[ix+0] = floor
+				b.Items[ix+0] = floor
 				continue
 			}
 			if name, found := strings.CutSuffix(part, "-compatible microchip"); found {
 				ix := names[name]
-				result[ix+1] = floor
+				b.Items[ix+1] = floor
 				continue
 			}
 		}
 	}
-	return result, nil
+	for item, floor := range b.Items {
+		if floor == 0 {
+			return b, fmt.Errorf("no floor for the %s", itemName(b.Names, item))
+		}
+	}
+	return b, nil
 }
 
-func parseFile(filename string) ([]int, error) {
+func ParseFile(filename string) (Building, error) {
 	content, err := utils.ReadAllFile(filename)
 	if err != nil {
-		return nil, err
+		return Building{}, err
 	}
-	return parseContent(content)
+	return ParseBuilding(content)
 }
 
 type State1 struct {
@@ -372,13 +381,15 @@ func (s State3) Hash() string {
 	return sb.String()
 }
 
+// The Goal states only handle the four floors of the puzzle with an elevator
+// carrying two items, see Building for other puzzles.
 type Goal interface {
 	Steps() int
 	Done() bool
 	Valid() bool
 	Hash() string
 	WithSteps(steps int) Goal
-	EnqueueNextSteps(c *CounterX)
+	NextSteps(yield func(next Goal))
 }
 
 func (s State1) WithSteps(steps int) Goal {
@@ -388,7 +399,7 @@ func (s State1) WithSteps(steps int) Goal {
 func (s State1) Steps() int {
 	return s.steps
 }
-func (s State1) EnqueueNextSteps(c *CounterX) {
+func (s State1) NextSteps(yield func(next Goal)) {
 	for d := 1; d >= -1; d -= 2 {
 		fc := s.floor
 		fn := s.floor + d
@@ -399,12 +410,12 @@ func (s State1) EnqueueNextSteps(c *CounterX) {
 					s1.steps++
 					s1.floor = fn
 					s1.Move(i, fc, fn)
-					c.Enqueue(s1)
+					yield(s1)
 					for j := i + 1; j < s.count*2; j++ {
 						if s.components[fc]&(1<<j) > 0 && ((i%2 == 0 && i+1 == j || j%2 == 0) || (i%2 == 1 && j%2 == 1)) {
 							s2 := s1.Copy()
 							s2.Move(j, fc, fn)
-							c.Enqueue(s2)
+							yield(s2)
 						}
 					}
 				}
@@ -420,7 +431,7 @@ func (s State2) WithSteps(steps int) Goal {
 func (s State2) Steps() int {
 	return s.steps
 }
-func (s State2) EnqueueNextSteps(c *CounterX) {
+func (s State2) NextSteps(yield func(next Goal)) {
 	for d := -1; d <= 1; d += 2 {
 		fc := s.floor
 		fn := s.floor + d
@@ -431,12 +442,12 @@ func (s State2) EnqueueNextSteps(c *CounterX) {
 					s1.steps++
 					s1.floor = fn
 					s1.floors[i] = fn
-					c.Enqueue(s1)
+					yield(s1)
 					for j, f2 := range s.floors {
 						if i < j && f2 == fc {
 							s2 := s1.Copy()
 							s2.floors[j] = fn
-							c.Enqueue(s2)
+							yield(s2)
 						}
 					}
 				}
@@ -454,7 +465,7 @@ func (s State3) WithSteps(steps int) Goal {
 func (s State3) Steps() int {
 	return s[INDEX_STEPS]
 }
-func (s State3) EnqueueNextSteps(c *CounterX) {
+func (s State3) NextSteps(yield func(next Goal)) {
 	for nextFloor := -1; nextFloor <= 1; nextFloor += 2 {
 		if s[INDEX_ELEVATOR]+nextFloor < FLOOR_BOTTOM || s[INDEX_ELEVATOR]+nextFloor > FLOOR_TOP {
 			continue
@@ -466,13 +477,13 @@ func (s State3) EnqueueNextSteps(c *CounterX) {
 				nextStep1[INDEX_STEPS]++
 				nextStep1[INDEX_ELEVATOR] += nextFloor
 				nextStep1[item1] += nextFloor
-				c.Enqueue(State3(nextStep1))
+				yield(State3(nextStep1))
 				for item2 := START_ITEMS; item2 < len(s); item2++ {
 					if nextStep1[item2] == s[INDEX_ELEVATOR] {
 						nextStep2 := make([]int, len(s))
 						copy(nextStep2, nextStep1)
 						nextStep2[item2] += nextFloor
-						c.Enqueue(State3(nextStep2))
+						yield(State3(nextStep2))
 					}
 				}
 			}
@@ -480,76 +491,14 @@ func (s State3) EnqueueNextSteps(c *CounterX) {
 	}
 }
 
-type PriorityQueueX []Goal
-
-func (pq PriorityQueueX) Len() int {
-	return len(pq)
-}
-func (pq PriorityQueueX) Less(i, j int) bool {
-	return pq[i].Steps() < pq[j].Steps()
-}
-func (pq PriorityQueueX) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-}
-func (pq *PriorityQueueX) Push(x any) {
-	item := x.(Goal)
-	*pq = append(*pq, item)
-}
-func (pq *PriorityQueueX) Pop() any {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	*pq = old[0 : n-1]
-	return item
-}
-
-type CounterX struct {
-	pq   PriorityQueueX
-	seen map[string]int
-	best Goal
-}
-
-func NewCounterX(initialState Goal) CounterX {
-	var c CounterX
-	c.seen = make(map[string]int)
-	c.pq = make([]Goal, 0, 1024)
-	c.pq = append(c.pq, initialState)
-	c.best = initialState.WithSteps(1 << 32)
-	return c
-}
-
-func (c *CounterX) Enqueue(s Goal) {
-	if s.Valid() {
-		key := s.Hash()
-		steps, found := c.seen[key]
-		if found {
-			if s.Steps() < steps {
-				c.seen[key] = s.Steps()
-				heap.Push(&c.pq, s)
-			}
-		} else {
-			c.seen[key] = s.Steps()
-			heap.Push(&c.pq, s)
-		}
-	}
-}
-func (c *CounterX) Run() {
-	for len(c.pq) > 0 {
-		s := heap.Pop(&c.pq).(Goal)
-		if s.Steps() < c.best.Steps() {
-			if s.Done() {
-				c.best = s
-				continue
-			}
-			s.EnqueueNextSteps(c)
-		}
-	}
-}
-
 const VERSION int = 1
 
 func goalFromFloors(floors []int) Goal {
-	switch VERSION {
+	return goalFromVersion(VERSION, floors)
+}
+
+func goalFromVersion(version int, floors []int) Goal {
+	switch version {
 	case 1:
 		return state1FromFloors(floors)
 	case 2:
@@ -561,32 +510,56 @@ func goalFromFloors(floors []int) Goal {
 	return none
 }
 
-func countStepsX(floors []int) int {
-	c := NewCounterX(goalFromFloors(floors))
-	c.Run()
-	return c.best.Steps()
+func solveX(floors []int) search.Result[Goal] {
+	return solveGoal(goalFromFloors(floors))
+}
+
+func solveGoal(start Goal) search.Result[Goal] {
+	return search.BFS(search.Problem[Goal, string]{
+		Start: start,
+		Key:   Goal.Hash,
+		Neighbors: func(s Goal, yield func(next Goal, cost int)) {
+			s.NextSteps(func(next Goal) {
+				if next.Valid() {
+					yield(next, 1)
+				}
+			})
+		},
+		Goal: Goal.Done,
+	})
 }
 
-func part1(floors []int) int {
-	return countStepsX(floors)
+func countStepsX(floors []int) int {
+	return solveX(floors).Cost
 }
 
-func part2(floors []int) int {
-	// Extras to the 1st floor:
-	//    An elerium generator.
-	//    An elerium-compatible microchip.
-	//    A dilithium generator.
-	//    A dilithium-compatible microchip.
-	floors2 := make([]int, len(floors)+4)
-	copy(floors2, floors)
-	for i := len(floors); i < len(floors2); i++ {
-		floors2[i] = 1
+// countSteps returns -1 when the items cannot all reach the top floor.
+func countSteps(b Building) int {
+	steps, err := b.CountSteps()
+	if err != nil {
+		return -1
 	}
-	return countStepsX(floors2)
+	return steps
+}
+
+func part1(b Building) int {
+	return countSteps(b)
+}
+
+// Extras to the 1st floor:
+//
+//	An elerium generator.
+//	An elerium-compatible microchip.
+//	A dilithium generator.
+//	A dilithium-compatible microchip.
+var EXTRA_ELEMENTS = []string{"elerium", "dilithium"}
+
+func part2(b Building) int {
+	return countSteps(b.WithElements(FLOOR_BOTTOM, EXTRA_ELEMENTS...))
 }
 
 func Solve() {
-	input, err := parseFile("./inputs/day-11.txt")
+	input, err := ParseFile("./inputs/day-11.txt")
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -17,12 +17,12 @@ The fourth floor contains nothing relevant.`
 	}
 
 	for _, test := range tests {
-		floors, err := parseContent(test.content)
+		b, err := ParseBuilding(test.content)
 		if err != nil {
-			t.Errorf("parseContent(%s) failed prematurely", test.content)
+			t.Errorf("ParseBuilding(%s) failed prematurely", test.content)
 			return
 		}
-		result := part1(floors)
+		result := part1(b)
 		if result != test.expected {
 			t.Errorf("part1(%v) = %v; want %v", test.content, result, test.expected)
 		}
@@ -0,0 +1,235 @@
+package day11
+
+import (
+	"fmt"
+	"math/bits"
+
+	"aoc2016/internal/search"
+)
+
+// Packed is a canonical state in a single integer: the elevator floor in the
+// lowest ELEVATOR_BITS bits followed by the (generator, microchip) floor
+// pairs sorted in increasing order. Pairs are interchangeable, so every
+// state with the same pair histogram has the same Packed value.
+type Packed uint64
+
+const (
+	ELEVATOR_BITS = 4
+	MAX_FLOORS    = 1 << ELEVATOR_BITS
+	MAX_PAIRS     = (64 - ELEVATOR_BITS) / 2
+)
+
+// Packer encodes the states of a building with a given number of floors and
+// element pairs, floors are counted from 0 once packed.
+type Packer struct {
+	Floors   int
+	Pairs    int
+	Capacity int
+	// Bits used by a single floor number
+	bits uint
+	mask uint64
+}
+
+func NewPacker(floors, pairs, capacity int) (Packer, error) {
+	var p Packer
+	p.Capacity = capacity
+	if floors < 2 || floors > MAX_FLOORS {
+		return p, fmt.Errorf("invalid number of floors: %d, want 2 to %d", floors, MAX_FLOORS)
+	}
+	p.Floors = floors
+	p.Pairs = pairs
+	p.bits = uint(bits.Len(uint(floors - 1)))
+	p.mask = 1<<p.bits - 1
+	if pairs < 0 || pairs > MAX_PAIRS || ELEVATOR_BITS+uint(pairs)*2*p.bits > 64 {
+		return p, fmt.Errorf("%d pairs on %d floors do not fit in 64 bits", pairs, floors)
+	}
+	return p, nil
+}
+
+// The floors of every item, a generator at 2*i and its microchip at 2*i+1
+type layout struct {
+	elevator int
+	items    [2 * MAX_PAIRS]uint8
+}
+
+func (p *Packer) pack(l *layout) Packed {
+	var pairs [MAX_PAIRS]uint64
+	for i := 0; i < p.Pairs; i++ {
+		pair := uint64(l.items[2*i])<<p.bits | uint64(l.items[2*i+1])
+		// Insertion sort, there are only a few pairs
+		k := i
+		for ; k > 0 && pairs[k-1] > pair; k-- {
+			pairs[k] = pairs[k-1]
+		}
+		pairs[k] = pair
+	}
+	result := uint64(l.elevator)
+	shift := uint(ELEVATOR_BITS)
+	for i := 0; i < p.Pairs; i++ {
+		result |= pairs[i] << shift
+		shift += 2 * p.bits
+	}
+	return Packed(result)
+}
+
+func (p *Packer) unpack(s Packed) layout {
+	var l layout
+	l.elevator = int(s & (MAX_FLOORS - 1))
+	v := uint64(s) >> ELEVATOR_BITS
+	for i := 0; i < p.Pairs; i++ {
+		l.items[2*i+1] = uint8(v & p.mask)
+		v >>= p.bits
+		l.items[2*i] = uint8(v & p.mask)
+		v >>= p.bits
+	}
+	return l
+}
+
+func (p *Packer) layout(elevator int, items []int) layout {
+	var l layout
+	l.elevator = elevator - 1
+	for i, f := range items {
+		l.items[i] = uint8(f - 1)
+	}
+	return l
+}
+
+// Pack encodes floors counted from 1 as parsed.
+func (p *Packer) Pack(elevator int, items []int) Packed {
+	l := p.layout(elevator, items)
+	return p.pack(&l)
+}
+
+// valid checks that no microchip shares a floor with a generator unless its
+// own generator is there too.
+func (p *Packer) valid(l *layout) bool {
+	var generators uint32
+	for i := 0; i < p.Pairs; i++ {
+		generators |= 1 << l.items[2*i]
+	}
+	for i := 0; i < p.Pairs; i++ {
+		chip := l.items[2*i+1]
+		if chip != l.items[2*i] && generators&(1<<chip) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Packer) Done(s Packed) bool {
+	top := uint64(p.Floors - 1)
+	v := uint64(s) >> ELEVATOR_BITS
+	for i := 0; i < 2*p.Pairs; i++ {
+		if v&p.mask != top {
+			return false
+		}
+		v >>= p.bits
+	}
+	return true
+}
+
+// NextSteps calls yield with every valid state reached by taking from one
+// item to Capacity items from the floor of the elevator to the floor above
+// or below.
+func (p *Packer) NextSteps(s Packed, yield func(next Packed)) {
+	l := p.unpack(s)
+	p.moves(&l, func(n *layout) bool {
+		yield(p.pack(n))
+		return true
+	})
+}
+
+// moves calls yield with the layouts reached from l until it returns false.
+func (p *Packer) moves(l *layout, yield func(n *layout) bool) {
+	floor := uint8(l.elevator)
+	var here [2 * MAX_PAIRS]int
+	count := 0
+	below := false
+	for i := 0; i < 2*p.Pairs; i++ {
+		if l.items[i] == floor {
+			here[count] = i
+			count++
+		}
+		below = below || l.items[i] < floor
+	}
+	for d := 1; d >= -1; d -= 2 {
+		next := int(floor) + d
+		if next < 0 || next >= p.Floors {
+			continue
+		}
+		// Nothing needs to go back to empty floors
+		if d < 0 && !below {
+			continue
+		}
+		n := *l
+		n.elevator = next
+		if !p.load(&n, here[:count], uint8(next), p.Capacity, yield) {
+			return
+		}
+	}
+}
+
+// load moves every combination of up to capacity items among here to next.
+func (p *Packer) load(n *layout, here []int, next uint8, capacity int, yield func(n *layout) bool) bool {
+	for k, item := range here {
+		from := n.items[item]
+		n.items[item] = next
+		if p.valid(n) && !yield(n) {
+			return false
+		}
+		if capacity > 1 && !p.load(n, here[k+1:], next, capacity-1, yield) {
+			return false
+		}
+		n.items[item] = from
+	}
+	return true
+}
+
+func (p *Packer) Problem(start Packed) search.Problem[Packed, Packed] {
+	return search.Problem[Packed, Packed]{
+		Start: start,
+		Key:   func(s Packed) Packed { return s },
+		Neighbors: func(s Packed, yield func(next Packed, cost int)) {
+			p.NextSteps(s, func(next Packed) { yield(next, 1) })
+		},
+		Goal:      p.Done,
+		Heuristic: p.Heuristic,
+	}
+}
+
+// Heuristic is a lower bound of the moves left. Every move crosses one gap
+// between two floors, and the n items below a gap need enough crossings:
+// with the elevator below, each trip up brings at most Capacity items and
+// each trip down takes at least one back, so 2*d+1 crossings for
+// d >= (n-Capacity)/(Capacity-1). With the elevator above, the first
+// crossing is down and 2*u crossings are needed for u >= n/(Capacity-1).
+func (p *Packer) Heuristic(s Packed) int {
+	l := p.unpack(s)
+	var count [MAX_FLOORS]int
+	for i := 0; i < 2*p.Pairs; i++ {
+		count[l.items[i]]++
+	}
+	result := 0
+	below := 0
+	for gap := 0; gap < p.Floors-1; gap++ {
+		below += count[gap]
+		if below == 0 {
+			continue
+		}
+		if p.Capacity == 1 {
+			// Nothing goes up for good, at least one crossing
+			result++
+			continue
+		}
+		if l.elevator <= gap {
+			result += 2*ceilDiv(max(below-p.Capacity, 0), p.Capacity-1) + 1
+		} else {
+			result += 2 * ceilDiv(below, p.Capacity-1)
+		}
+	}
+	return result
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,122 @@
+package day11
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"aoc2016/internal/search"
+)
+
+func TestPackedCanonical(t *testing.T) {
+	p, err := NewPacker(4, 3, 2)
+	if err != nil {
+		t.Fatalf("NewPacker(4, 3, 2) = error '%v'", err)
+	}
+	// The same building with its pairs listed in another order
+	a := p.Pack(1, []int{1, 2, 3, 3, 4, 1})
+	b := p.Pack(1, []int{4, 1, 3, 3, 1, 2})
+	if a != b {
+		t.Errorf("Pack() = %x and %x, want the same state", a, b)
+	}
+	if c := p.Pack(1, []int{1, 2, 3, 3, 1, 4}); a == c {
+		t.Errorf("Pack() = %x for different buildings", a)
+	}
+	if !p.Done(p.Pack(4, []int{4, 4, 4, 4, 4, 4})) || p.Done(a) {
+		t.Errorf("Done() should only hold with every item on the top floor")
+	}
+}
+
+func TestNewPacker(t *testing.T) {
+	tests := []struct {
+		floors int
+		pairs  int
+		valid  bool
+	}{
+		{floors: 4, pairs: 15, valid: true},
+		{floors: 4, pairs: 16, valid: false},
+		{floors: 8, pairs: 10, valid: true},
+		{floors: 8, pairs: 11, valid: false},
+		{floors: 16, pairs: 7, valid: true},
+		{floors: 17, pairs: 1, valid: false},
+		{floors: 1, pairs: 1, valid: false},
+	}
+
+	for _, test := range tests {
+		_, err := NewPacker(test.floors, test.pairs, CAPACITY)
+		if (err == nil) != test.valid {
+			t.Errorf("NewPacker(%d, %d) = error '%v', want valid %v", test.floors, test.pairs, err, test.valid)
+		}
+	}
+}
+
+// The packed states must give the same answers as State3, which moves any
+// one or two items like Packer.
+func TestPackedRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(11, 2016))
+	for n := 0; n < 200; n++ {
+		floors := make([]int, 2*(1+r.IntN(3)))
+		for i := range floors {
+			floors[i] = 1 + r.IntN(FLOOR_TOP)
+		}
+		if !state3FromFloors(floors).Valid() {
+			continue
+		}
+		expected := -1
+		if result := solveGoal(state3FromFloors(floors)); result.Found {
+			expected = result.Cost
+		}
+		b := Building{Floors: FLOOR_TOP, Capacity: CAPACITY, Elevator: 1, Items: floors}
+		for i := 0; i < len(floors)/2; i++ {
+			b.Names = append(b.Names, fmt.Sprint(i))
+		}
+		result, err := b.CountSteps()
+		if err != nil {
+			t.Fatalf("CountSteps(%v) = error '%v'", floors, err)
+		}
+		if result != expected {
+			t.Errorf("CountSteps(%v) = %d, want %d", floors, result, expected)
+		}
+	}
+}
+
+// Every item starts on the 1st floor.
+func groundFloor(pairs int) []int {
+	floors := make([]int, 2*pairs)
+	for i := range floors {
+		floors[i] = 1
+	}
+	return floors
+}
+
+func BenchmarkPacked(b *testing.B) {
+	for _, size := range []struct{ floors, pairs int }{
+		{4, 2}, {4, 4}, {4, 6}, {4, 8}, {4, 10}, {4, 12},
+		{6, 2}, {6, 4}, {6, 6},
+		{8, 2}, {8, 4},
+	} {
+		b.Run(fmt.Sprintf("floors=%d/pairs=%d", size.floors, size.pairs), func(b *testing.B) {
+			p, err := NewPacker(size.floors, size.pairs, CAPACITY)
+			if err != nil {
+				b.Fatal(err)
+			}
+			start := p.Pack(1, groundFloor(size.pairs))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				search.BFS(p.Problem(start))
+			}
+		})
+	}
+}
+
+func BenchmarkGoal(b *testing.B) {
+	for _, pairs := range []int{2, 4, 6} {
+		b.Run(fmt.Sprintf("floors=4/pairs=%d", pairs), func(b *testing.B) {
+			floors := groundFloor(pairs)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				countStepsX(floors)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package day11
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Move struct {
+	From  int
+	To    int
+	Items []int
+}
+
+// Moves compares each building of the path with the previous one.
+func Moves(path []Building) []Move {
+	moves := make([]Move, 0, len(path))
+	for i := 1; i < len(path); i++ {
+		var m Move
+		m.From = path[i-1].Elevator
+		m.To = path[i].Elevator
+		before, after := path[i-1].Items, path[i].Items
+		for item := range after {
+			if before[item] != after[item] {
+				m.Items = append(m.Items, item)
+			}
+		}
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+func itemName(names []string, item int) string {
+	if item%2 == 0 {
+		return names[item/2] + " generator"
+	}
+	return names[item/2] + "-compatible microchip"
+}
+
+func (m Move) Describe(names []string) string {
+	items := make([]string, len(m.Items))
+	for i, item := range m.Items {
+		items[i] = "the " + itemName(names, item)
+	}
+	list := items[0]
+	if len(items) > 1 {
+		list = strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+	}
+	return fmt.Sprintf("bring %s from floor %d to floor %d", list, m.From, m.To)
+}
+
+// Abbreviations like HG and HM for the hydrogen generator and microchip, the
+// element is shortened to its shortest prefix that tells it apart. Names that
+// only differ by case get a number after their prefix, and an empty name is
+// written as ?.
+func Abbreviations(names []string) []string {
+	longest := 0
+	for _, name := range names {
+		longest = max(longest, utf8.RuneCountInString(name))
+	}
+	prefix := func(name string, size int) string {
+		if name == "" {
+			return "?"
+		}
+		rs := []rune(name)
+		return strings.ToUpper(string(rs[0])) + string(rs[1:min(size, len(rs))])
+	}
+	size := 1
+	for ; size < longest; size++ {
+		seen := make(map[string]bool)
+		unique := true
+		for _, name := range names {
+			key := strings.ToUpper(prefix(name, size))
+			if seen[key] {
+				unique = false
+			}
+			seen[key] = true
+		}
+		if unique {
+			break
+		}
+	}
+	prefixes := make([]string, len(names))
+	seen := make(map[string]bool)
+	for i, name := range names {
+		prefixes[i] = prefix(name, size)
+		seen[strings.ToUpper(prefixes[i])] = true
+	}
+	taken := make(map[string]bool)
+	for i := range prefixes {
+		key := strings.ToUpper(prefixes[i])
+		if taken[key] {
+			n := 2
+			for seen[key+strconv.Itoa(n)] {
+				n++
+			}
+			prefixes[i] += strconv.Itoa(n)
+			key += strconv.Itoa(n)
+			seen[key] = true
+		}
+		taken[key] = true
+	}
+	result := make([]string, 0, 2*len(names))
+	for _, p := range prefixes {
+		result = append(result, p+"G", p+"M")
+	}
+	return result
+}
+
+// Diagram draws the floors like the puzzle text, top floor first.
+func Diagram(b Building) string {
+	labels := Abbreviations(b.Names)
+	width := 2
+	for _, label := range labels {
+		width = max(width, utf8.RuneCountInString(label)+1)
+	}
+	var sb strings.Builder
+	width0 := len(fmt.Sprint(b.Floors)) + 2
+	for floor := b.Floors; floor >= FLOOR_BOTTOM; floor-- {
+		cells := make([]string, 0, len(b.Items)+1)
+		if b.Elevator == floor {
+			cells = append(cells, "E")
+		} else {
+			cells = append(cells, ".")
+		}
+		for item, f := range b.Items {
+			if f == floor {
+				cells = append(cells, labels[item])
+			} else {
+				cells = append(cells, ".")
+			}
+		}
+		line := fmt.Sprintf("%-*s", width0, fmt.Sprintf("F%d", floor))
+		for _, cell := range cells {
+			line += fmt.Sprintf("%-*s", width, cell)
+		}
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Replay writes every move of a shortest solution with the floors after it.
+func Replay(w io.Writer, b Building) error {
+	path, err := b.Path()
+	if err != nil {
+		return err
+	}
+	if path == nil {
+		_, err := fmt.Fprintln(w, "No solution")
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Start:\n%s", Diagram(b)); err != nil {
+		return err
+	}
+	for i, m := range Moves(path) {
+		_, err := fmt.Fprintf(w, "\nStep %d: %s\n%s", i+1, m.Describe(b.Names), Diagram(path[i+1]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
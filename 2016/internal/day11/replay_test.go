@@ -0,0 +1,89 @@
+package day11
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+const example = `The first floor contains a hydrogen-compatible microchip and a lithium-compatible microchip.
+The second floor contains a hydrogen generator.
+The third floor contains a lithium generator.
+The fourth floor contains nothing relevant.`
+
+func TestDiagram(t *testing.T) {
+	b, err := ParseBuilding(example)
+	if err != nil {
+		t.Fatalf("ParseBuilding(%s) = error '%v'", example, err)
+	}
+	expected := "F4 .  .  .  .  .\n" +
+		"F3 .  .  .  LG .\n" +
+		"F2 .  HG .  .  .\n" +
+		"F1 E  .  HM .  LM\n"
+	if result := Diagram(b); result != expected {
+		t.Errorf("Diagram() = \n%s\nwant\n%s", result, expected)
+	}
+}
+
+func TestMoves(t *testing.T) {
+	b, err := ParseBuilding(example)
+	if err != nil {
+		t.Fatalf("ParseBuilding(%s) = error '%v'", example, err)
+	}
+	for _, capacity := range []int{2, 3} {
+		b.Capacity = capacity
+		path, err := b.Path()
+		if err != nil {
+			t.Fatalf("Path() = error '%v'", err)
+		}
+		steps, _ := b.CountSteps()
+		moves := Moves(path)
+		if len(moves) != steps {
+			t.Fatalf("Moves() = %d moves, want %d", len(moves), steps)
+		}
+		// Replaying the moves from the start must reach the top floor safely
+		items := slices.Clone(b.Items)
+		elevator := b.Elevator
+		for i, m := range moves {
+			if m.From != elevator || len(m.Items) < 1 || len(m.Items) > capacity || m.To-m.From != 1 && m.From-m.To != 1 {
+				t.Fatalf("Moves()[%d] = %s, invalid from floor %d", i, m.Describe(b.Names), elevator)
+			}
+			for _, item := range m.Items {
+				if items[item] != m.From {
+					t.Fatalf("Moves()[%d] = %s, the item is not on floor %d", i, m.Describe(b.Names), m.From)
+				}
+				items[item] = m.To
+			}
+			elevator = m.To
+			if !reflect.DeepEqual(items, path[i+1].Items) || !path[i+1].Safe() {
+				t.Fatalf("Moves()[%d] = %s, gives %v, want %v", i, m.Describe(b.Names), items, path[i+1].Items)
+			}
+		}
+		if !path[len(path)-1].Done() {
+			t.Errorf("Moves() does not end with every item on the top floor")
+		}
+	}
+}
+
+func TestAbbreviations(t *testing.T) {
+	tests := []struct {
+		names    []string
+		expected []string
+	}{
+		{names: []string{"hydrogen", "lithium"}, expected: []string{"HG", "HM", "LG", "LM"}},
+		{names: []string{"promethium", "plutonium"}, expected: []string{"PrG", "PrM", "PlG", "PlM"}},
+		{names: []string{"Iron", "iron"}, expected: []string{"IronG", "IronM", "Iron2G", "Iron2M"}},
+		{names: []string{"iron", "Iron", "iron"}, expected: []string{"IronG", "IronM", "Iron2G", "Iron2M", "Iron3G", "Iron3M"}},
+		{names: []string{"", "lithium"}, expected: []string{"?G", "?M", "LG", "LM"}},
+		{names: []string{"", ""}, expected: []string{"?G", "?M", "?2G", "?2M"}},
+		{names: []string{"co", "cobalt"}, expected: []string{"CoG", "CoM", "CobG", "CobM"}},
+		{names: []string{"ölium", "örium"}, expected: []string{"ÖlG", "ÖlM", "ÖrG", "ÖrM"}},
+		{names: []string{"éther", "eau"}, expected: []string{"ÉG", "ÉM", "EG", "EM"}},
+	}
+
+	for _, test := range tests {
+		if result := Abbreviations(test.names); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Abbreviations(%v) = %v, want %v", test.names, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package day11
+
+import (
+	"fmt"
+	"time"
+
+	"aoc2016/internal/search"
+)
+
+type Strategy int
+
+const (
+	// Breadth first search on the packed states
+	BFS Strategy = iota
+	// A* on the packed states with Packer.Heuristic
+	ASTAR
+	// Breadth first search on the Goal states of each VERSION
+	VERSION1
+	VERSION2
+	VERSION3
+)
+
+var STRATEGIES = []Strategy{BFS, ASTAR, VERSION1, VERSION2, VERSION3}
+
+func (s Strategy) String() string {
+	switch s {
+	case BFS:
+		return "bfs"
+	case ASTAR:
+		return "astar"
+	case VERSION1:
+		return "version1"
+	case VERSION2:
+		return "version2"
+	case VERSION3:
+		return "version3"
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+func StrategyFromString(text string) (Strategy, error) {
+	for _, s := range STRATEGIES {
+		if s.String() == text {
+			return s, nil
+		}
+	}
+	return BFS, fmt.Errorf("invalid strategy: %s", text)
+}
+
+type Report struct {
+	Strategy Strategy
+	// -1 when the items cannot all reach the top floor
+	Steps   int
+	Stats   search.Stats
+	Elapsed time.Duration
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("%-8s %6d steps %10d expanded %10d enqueued %10d peak queue %12v",
+		r.Strategy, r.Steps, r.Stats.Expanded, r.Stats.Enqueued, r.Stats.PeakQueue, r.Elapsed)
+}
+
+// Search counts the moves with the given strategy. The Goal versions only
+// solve buildings with four floors, an elevator for two items starting on
+// the 1st floor.
+func (b Building) Search(strategy Strategy) (Report, error) {
+	report := Report{Strategy: strategy, Steps: -1}
+	p, err := b.Packer()
+	if err != nil {
+		return report, err
+	}
+	start := time.Now()
+	var found bool
+	switch strategy {
+	case BFS, ASTAR:
+		problem := p.Problem(p.Pack(b.Elevator, b.Items))
+		var r search.Result[Packed]
+		if strategy == BFS {
+			r = search.BFS(problem)
+		} else {
+			r = search.AStar(problem)
+		}
+		found, report.Steps, report.Stats = r.Found, r.Cost, r.Stats
+	case VERSION1, VERSION2, VERSION3:
+		if b.Floors != FLOOR_TOP || b.Capacity != CAPACITY || b.Elevator != FLOOR_BOTTOM {
+			return report, fmt.Errorf("%s only solves %d floors with an elevator for %d items on the 1st floor", strategy, FLOOR_TOP, CAPACITY)
+		}
+		r := solveGoal(goalFromVersion(int(strategy-VERSION1)+1, b.Items))
+		found, report.Steps, report.Stats = r.Found, r.Cost, r.Stats
+	default:
+		return report, fmt.Errorf("invalid strategy: %d", strategy)
+	}
+	report.Elapsed = time.Since(start)
+	if !found {
+		report.Steps = -1
+	}
+	return report, nil
+}
+
+// Compare runs every strategy that can solve the building.
+func (b Building) Compare() ([]Report, error) {
+	var reports []Report
+	for _, strategy := range STRATEGIES {
+		report, err := b.Search(strategy)
+		if err != nil {
+			if strategy < VERSION1 {
+				return reports, err
+			}
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
@@ -0,0 +1,108 @@
+package day11
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestStrategiesAgree(t *testing.T) {
+	r := rand.New(rand.NewPCG(39, 2016))
+	for n := 0; n < 100; n++ {
+		b := Building{Floors: 4, Capacity: CAPACITY, Elevator: FLOOR_BOTTOM}
+		pairs := 1 + r.IntN(3)
+		for i := 0; i < pairs; i++ {
+			b = b.WithElements(1+r.IntN(b.Floors), fmt.Sprint(i))
+			b.Items[2*i+1] = 1 + r.IntN(b.Floors)
+		}
+		if !b.Safe() {
+			continue
+		}
+		reports, err := b.Compare()
+		if err != nil {
+			t.Fatalf("Compare(%v) = error '%v'", b.Items, err)
+		}
+		if len(reports) != len(STRATEGIES) {
+			t.Fatalf("Compare(%v) = %d reports, want %d", b.Items, len(reports), len(STRATEGIES))
+		}
+		for _, report := range reports[1:] {
+			if report.Steps != reports[0].Steps {
+				t.Errorf("Search(%v, %s) = %d, want %d", b.Items, report.Strategy, report.Steps, reports[0].Steps)
+			}
+		}
+	}
+}
+
+// The heuristic must never overestimate, A* then finds the same number of
+// moves as BFS on other floors and capacities too.
+func TestHeuristic(t *testing.T) {
+	r := rand.New(rand.NewPCG(2016, 39))
+	for n := 0; n < 100; n++ {
+		b := Building{Floors: 2 + r.IntN(4), Capacity: 1 + r.IntN(3)}
+		b.Elevator = 1 + r.IntN(b.Floors)
+		pairs := 1 + r.IntN(3)
+		for i := 0; i < pairs; i++ {
+			b = b.WithElements(1+r.IntN(b.Floors), fmt.Sprint(i))
+			b.Items[2*i+1] = 1 + r.IntN(b.Floors)
+		}
+		if !b.Safe() {
+			continue
+		}
+		bfs, err := b.Search(BFS)
+		if err != nil {
+			t.Fatalf("Search(%v, bfs) = error '%v'", b, err)
+		}
+		astar, _ := b.Search(ASTAR)
+		if astar.Steps != bfs.Steps {
+			t.Errorf("Search(%v, astar) = %d, want %d", b, astar.Steps, bfs.Steps)
+		}
+		p, _ := b.Packer()
+		if h := p.Heuristic(p.Pack(b.Elevator, b.Items)); bfs.Steps >= 0 && h > bfs.Steps {
+			t.Errorf("Heuristic(%v) = %d, more than %d moves", b, h, bfs.Steps)
+		}
+	}
+}
+
+func TestHeuristicExact(t *testing.T) {
+	// Moving n items up one floor takes 2*n-3 moves
+	for pairs := 2; pairs <= 6; pairs++ {
+		p, err := NewPacker(4, pairs, CAPACITY)
+		if err != nil {
+			t.Fatalf("NewPacker(4, %d) = error '%v'", pairs, err)
+		}
+		expected := 3 * (4*pairs - 3)
+		if result := p.Heuristic(p.Pack(1, groundFloor(pairs))); result != expected {
+			t.Errorf("Heuristic(%d pairs) = %d, want %d", pairs, result, expected)
+		}
+	}
+}
+
+func TestStrategyFromString(t *testing.T) {
+	for _, strategy := range STRATEGIES {
+		if result, err := StrategyFromString(strategy.String()); err != nil || result != strategy {
+			t.Errorf("StrategyFromString(%s) = %v, %v; want %v", strategy, result, err, strategy)
+		}
+	}
+	if _, err := StrategyFromString("dfs"); err == nil {
+		t.Errorf("StrategyFromString(dfs) = no error")
+	}
+}
+
+func BenchmarkStrategy(b *testing.B) {
+	for _, strategy := range STRATEGIES {
+		b.Run(fmt.Sprintf("%s/pairs=5", strategy), func(b *testing.B) {
+			building := Building{Floors: 4, Capacity: CAPACITY, Elevator: 1, Items: groundFloor(5)}
+			for i := 0; i < 5; i++ {
+				building.Names = append(building.Names, fmt.Sprint(i))
+			}
+			for i := 0; i < b.N; i++ {
+				report, err := building.Search(strategy)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportMetric(float64(report.Stats.Expanded), "expanded/op")
+				b.ReportMetric(float64(report.Stats.PeakQueue), "peak/op")
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package day13
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"unicode"
+)
+
+// The formula of the puzzle in the expression language
+const PUZZLE_FORMULA = "popcount(x*x + 3*x + 2*x*y + y + y*y + seed) % 2"
+
+// Formula is a wall function written as an expression of x, y and seed, a
+// point is a wall when the expression is not 0.
+//
+// The expression uses integers with the operators of Go and their
+// precedence: unary - ^ !, then * / % << >> &, + - | ^, == != < <= > >=, &&
+// and ||. Comparisons give 1 or 0. The functions are popcount(v), abs(v),
+// min(a, b) and max(a, b).
+type Formula struct {
+	Text string
+	Seed int
+	eval evaluator
+}
+
+func (f Formula) IsWall(p Point) bool {
+	return f.eval(p.X, p.Y, f.Seed) != 0
+}
+
+func (f Formula) Eval(p Point) int {
+	return f.eval(p.X, p.Y, f.Seed)
+}
+
+type formulaError struct {
+	offset int
+	msg    string
+}
+
+func (e *formulaError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.offset, e.msg)
+}
+
+func ParseFormula(text string, seed int) (Formula, error) {
+	p := formulaParser{text: text}
+	eval, err := p.parse()
+	if err != nil {
+		return Formula{}, err
+	}
+	return Formula{Text: text, Seed: seed, eval: eval}, nil
+}
+
+type evaluator func(x, y, seed int) int
+
+type formulaParser struct {
+	text string
+	pos  int
+}
+
+func (p *formulaParser) fail(format string, args ...any) error {
+	return &formulaError{offset: p.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *formulaParser) skipSpaces() {
+	for p.pos < len(p.text) && unicode.IsSpace(rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept consumes op when it comes next and is not the start of a longer
+// operator, like < in <<.
+func (p *formulaParser) accept(op string) bool {
+	p.skipSpaces()
+	if len(p.text)-p.pos < len(op) || p.text[p.pos:p.pos+len(op)] != op {
+		return false
+	}
+	next := p.pos + len(op)
+	if next < len(p.text) {
+		for _, longer := range []string{"<<", ">>", "<=", ">=", "==", "!=", "&&", "||"} {
+			if len(longer) > len(op) && longer[:len(op)] == op && longer[len(op)] == p.text[next] {
+				return false
+			}
+		}
+	}
+	p.pos = next
+	return true
+}
+
+func (p *formulaParser) parse() (evaluator, error) {
+	e, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.text) {
+		return nil, p.fail("unexpected %q", p.text[p.pos])
+	}
+	return e, nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Operators from the lowest precedence to the highest
+var LEVELS = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<=", ">=", "<", ">"},
+	{"+", "-", "|", "^"},
+	{"*", "/", "%", "<<", ">>", "&"},
+}
+
+var OPERATORS = map[string]func(a, b int) int{
+	"||": func(a, b int) int { return boolInt(a != 0 || b != 0) },
+	"&&": func(a, b int) int { return boolInt(a != 0 && b != 0) },
+	"==": func(a, b int) int { return boolInt(a == b) },
+	"!=": func(a, b int) int { return boolInt(a != b) },
+	"<=": func(a, b int) int { return boolInt(a <= b) },
+	">=": func(a, b int) int { return boolInt(a >= b) },
+	"<":  func(a, b int) int { return boolInt(a < b) },
+	">":  func(a, b int) int { return boolInt(a > b) },
+	"+":  func(a, b int) int { return a + b },
+	"-":  func(a, b int) int { return a - b },
+	"|":  func(a, b int) int { return a | b },
+	"^":  func(a, b int) int { return a ^ b },
+	"*":  func(a, b int) int { return a * b },
+	// Dividing by 0 makes a wall instead of failing
+	"/": func(a, b int) int {
+		if b == 0 {
+			return 1
+		}
+		return a / b
+	},
+	"%": func(a, b int) int {
+		if b == 0 {
+			return 1
+		}
+		return a % b
+	},
+	"<<": func(a, b int) int { return a << (uint(b) & 63) },
+	">>": func(a, b int) int { return a >> (uint(b) & 63) },
+	"&":  func(a, b int) int { return a & b },
+}
+
+func (p *formulaParser) binary(level int) (evaluator, error) {
+	if level == len(LEVELS) {
+		return p.unary()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := ""
+		for _, candidate := range LEVELS[level] {
+			if p.accept(candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l, f := left, OPERATORS[op]
+		left = func(x, y, seed int) int {
+			return f(l(x, y, seed), right(x, y, seed))
+		}
+	}
+}
+
+func (p *formulaParser) unary() (evaluator, error) {
+	for _, op := range []string{"-", "^", "!"} {
+		if !p.accept(op) {
+			continue
+		}
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "-":
+			return func(x, y, seed int) int { return -e(x, y, seed) }, nil
+		case "^":
+			return func(x, y, seed int) int { return ^e(x, y, seed) }, nil
+		default:
+			return func(x, y, seed int) int { return boolInt(e(x, y, seed) == 0) }, nil
+		}
+	}
+	return p.operand()
+}
+
+func (p *formulaParser) operand() (evaluator, error) {
+	p.skipSpaces()
+	if p.pos == len(p.text) {
+		return nil, p.fail("unexpected end of formula")
+	}
+	if p.accept("(") {
+		e, err := p.binary(0)
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.fail("expected ')'")
+		}
+		return e, nil
+	}
+	start := p.pos
+	c := rune(p.text[p.pos])
+	if unicode.IsDigit(c) {
+		for p.pos < len(p.text) && unicode.IsDigit(rune(p.text[p.pos])) {
+			p.pos++
+		}
+		n, err := strconv.Atoi(p.text[start:p.pos])
+		if err != nil {
+			number := p.text[start:p.pos]
+			p.pos = start
+			return nil, p.fail("number %s out of range", number)
+		}
+		return func(x, y, seed int) int { return n }, nil
+	}
+	if !unicode.IsLetter(c) {
+		return nil, p.fail("unexpected %q", p.text[p.pos])
+	}
+	for p.pos < len(p.text) && (unicode.IsLetter(rune(p.text[p.pos])) || unicode.IsDigit(rune(p.text[p.pos]))) {
+		p.pos++
+	}
+	name := p.text[start:p.pos]
+	switch name {
+	case "x":
+		return func(x, y, seed int) int { return x }, nil
+	case "y":
+		return func(x, y, seed int) int { return y }, nil
+	case "seed":
+		return func(x, y, seed int) int { return seed }, nil
+	}
+	args, err := p.arguments(name, start)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "popcount":
+		a := args[0]
+		return func(x, y, seed int) int { return bits.OnesCount(uint(a(x, y, seed))) }, nil
+	case "abs":
+		a := args[0]
+		return func(x, y, seed int) int {
+			v := a(x, y, seed)
+			return max(v, -v)
+		}, nil
+	case "min":
+		a, b := args[0], args[1]
+		return func(x, y, seed int) int { return min(a(x, y, seed), b(x, y, seed)) }, nil
+	default:
+		a, b := args[0], args[1]
+		return func(x, y, seed int) int { return max(a(x, y, seed), b(x, y, seed)) }, nil
+	}
+}
+
+var FUNCTIONS = map[string]int{"popcount": 1, "abs": 1, "min": 2, "max": 2}
+
+func (p *formulaParser) arguments(name string, start int) ([]evaluator, error) {
+	arity, found := FUNCTIONS[name]
+	if !found {
+		p.pos = start
+		return nil, p.fail("unknown name %q", name)
+	}
+	if !p.accept("(") {
+		return nil, p.fail("expected '(' after %s", name)
+	}
+	var args []evaluator
+	for {
+		e, err := p.binary(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if !p.accept(")") {
+		return nil, p.fail("expected ')'")
+	}
+	if len(args) != arity {
+		p.pos = start
+		return nil, p.fail("%s takes %d arguments, not %d", name, arity, len(args))
+	}
+	return args, nil
+}
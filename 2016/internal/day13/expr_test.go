@@ -0,0 +1,79 @@
+package day13
+
+import (
+	"testing"
+)
+
+func TestFormulaPuzzle(t *testing.T) {
+	for _, seed := range []int{10, 1362, 1364} {
+		f, err := ParseFormula(PUZZLE_FORMULA, seed)
+		if err != nil {
+			t.Fatalf("ParseFormula(%s) = error '%v'", PUZZLE_FORMULA, err)
+		}
+		g := NewMapGenerator(seed)
+		for y := 0; y < 50; y++ {
+			for x := 0; x < 50; x++ {
+				p := NewPoint(x, y)
+				if f.IsWall(p) != g.IsWall(p) {
+					t.Fatalf("IsWall(%v) = %v with seed %d, want %v", p, f.IsWall(p), seed, g.IsWall(p))
+				}
+			}
+		}
+	}
+}
+
+func TestFormulaEval(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{text: "1 + 2 * 3", expected: 7},
+		{text: "(1 + 2) * 3", expected: 9},
+		{text: "x - y - 1", expected: 1},
+		{text: "-x + ^0", expected: -6},
+		{text: "1 << 4 >> 2", expected: 4},
+		{text: "x & 6 | 1", expected: 5},
+		{text: "x ^ y", expected: 6},
+		{text: "x % y == 2 && !(y > 3) || 0", expected: 1},
+		{text: "x <= y", expected: 0},
+		{text: "x != y", expected: 1},
+		{text: "popcount(255) + abs(-2) + min(x, y) + max(x, y)", expected: 18},
+		{text: "seed", expected: 42},
+		{text: "x / 0", expected: 1},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFormula(test.text, 42)
+		if err != nil {
+			t.Errorf("ParseFormula(%s) = error '%v'", test.text, err)
+			continue
+		}
+		if result := f.Eval(NewPoint(5, 3)); result != test.expected {
+			t.Errorf("Eval(%s) = %d, want %d", test.text, result, test.expected)
+		}
+	}
+}
+
+func TestFormulaErrors(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "", expected: "offset 0: unexpected end of formula"},
+		{text: "x +", expected: "offset 3: unexpected end of formula"},
+		{text: "(x + 1", expected: "offset 6: expected ')'"},
+		{text: "x y", expected: "offset 2: unexpected 'y'"},
+		{text: "z + 1", expected: "offset 0: unknown name \"z\""},
+		{text: "popcount x", expected: "offset 9: expected '(' after popcount"},
+		{text: "min(x)", expected: "offset 0: min takes 2 arguments, not 1"},
+		{text: "x = 1", expected: "offset 2: unexpected '='"},
+		{text: "99999999999999999999", expected: "offset 0: number 99999999999999999999 out of range"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseFormula(test.text, 0)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("ParseFormula(%s) = error '%v', want '%s'", test.text, err, test.expected)
+		}
+	}
+}
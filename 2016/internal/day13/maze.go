@@ -0,0 +1,202 @@
+package day13
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"aoc2016/internal/search"
+)
+
+// Maze explores the maze inside [0, Width) x [0, Height), procedural mazes
+// are infinite so the region bounds searches for unreachable points.
+type Maze struct {
+	Walls  WallFunc
+	Width  int
+	Height int
+}
+
+func NewMaze(w WallFunc, width, height int) Maze {
+	var m Maze
+	m.Walls = w
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Maze) Inside(p Point) bool {
+	return 0 <= p.X && p.X < m.Width && 0 <= p.Y && p.Y < m.Height
+}
+
+func (m *Maze) Open(p Point) bool {
+	return m.Inside(p) && !m.Walls.IsWall(p)
+}
+
+func (m *Maze) Problem(from, to Point) search.Problem[Point, Point] {
+	return search.Problem[Point, Point]{
+		Start: from,
+		Key:   func(p Point) Point { return p },
+		Neighbors: func(s Point, yield func(next Point, cost int)) {
+			Neighbors(m.Walls, s, func(next Point, cost int) {
+				if m.Inside(next) {
+					yield(next, cost)
+				}
+			})
+		},
+		Goal:      func(p Point) bool { return p == to },
+		Heuristic: to.Distance,
+	}
+}
+
+type Algorithm int
+
+const (
+	// A* ordered by steps plus Manhattan distance
+	ASTAR Algorithm = iota
+	BFS
+	// BFS from both ends until they meet
+	BIDIRECTIONAL
+)
+
+func AlgorithmFromString(text string) (Algorithm, error) {
+	switch text {
+	case "astar":
+		return ASTAR, nil
+	case "bfs":
+		return BFS, nil
+	case "bidirectional":
+		return BIDIRECTIONAL, nil
+	}
+	return ASTAR, fmt.Errorf("invalid algorithm: %s", text)
+}
+
+// Search finds a shortest path between two open points of the region.
+func (m *Maze) Search(from, to Point, algorithm Algorithm) search.Result[Point] {
+	if !m.Open(from) || !m.Open(to) {
+		return search.Result[Point]{}
+	}
+	p := m.Problem(from, to)
+	switch algorithm {
+	case BFS:
+		return search.BFS(p)
+	case BIDIRECTIONAL:
+		return search.Bidirectional(p, to)
+	}
+	return search.AStar(p)
+}
+
+// ShortestPath returns the points from one point to the other, both
+// included, or nil when they are not connected inside the region.
+func (m *Maze) ShortestPath(from, to Point) []Point {
+	return m.Search(from, to, ASTAR).Path
+}
+
+// Distances returns the steps from one point to each target in one pass, -1
+// for the targets that cannot be reached.
+func (m *Maze) Distances(from Point, targets []Point) []int {
+	if !m.Open(from) {
+		result := make([]int, len(targets))
+		for i := range result {
+			result[i] = -1
+		}
+		return result
+	}
+	return search.Distances(m.Problem(from, from), targets)
+}
+
+// Reachable returns the number of steps to every point reachable with at
+// most steps steps, without limit when steps is negative.
+func (m *Maze) Reachable(from Point, steps int) map[Point]int {
+	if !m.Open(from) {
+		return map[Point]int{}
+	}
+	return search.Reachable(m.Problem(from, from), steps)
+}
+
+// Component returns the points connected to from inside the region.
+func (m *Maze) Component(from Point) map[Point]int {
+	return m.Reachable(from, -1)
+}
+
+// Overlay marks points of the maze, explored points with their distance and
+// a path drawn over them.
+type Overlay struct {
+	Explored map[Point]int
+	Path     []Point
+}
+
+func (o *Overlay) onPath() map[Point]bool {
+	result := make(map[Point]bool, len(o.Path))
+	for _, p := range o.Path {
+		result[p] = true
+	}
+	return result
+}
+
+// Render draws the maze like the puzzle text, # for walls and . for open
+// space, with explored points as o and the path as O.
+func (m *Maze) Render(w io.Writer, o Overlay) error {
+	path := o.onPath()
+	bw := bufio.NewWriter(w)
+	bw.WriteString("  ")
+	for x := 0; x < m.Width; x++ {
+		fmt.Fprint(bw, x%10)
+	}
+	bw.WriteByte('\n')
+	for y := 0; y < m.Height; y++ {
+		fmt.Fprintf(bw, "%d ", y%10)
+		for x := 0; x < m.Width; x++ {
+			p := NewPoint(x, y)
+			_, explored := o.Explored[p]
+			switch {
+			case m.Walls.IsWall(p):
+				bw.WriteByte('#')
+			case path[p]:
+				bw.WriteByte('O')
+			case explored:
+				bw.WriteByte('o')
+			default:
+				bw.WriteByte('.')
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+var (
+	WALL_COLOR     = color.RGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xff}
+	OPEN_COLOR     = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	EXPLORED_COLOR = color.RGBA{R: 0xa0, G: 0xc8, B: 0xf0, A: 0xff}
+	PATH_COLOR     = color.RGBA{R: 0xe0, G: 0x30, B: 0x30, A: 0xff}
+)
+
+func (m *Maze) Image(o Overlay, scale int) image.Image {
+	scale = max(scale, 1)
+	path := o.onPath()
+	img := image.NewRGBA(image.Rect(0, 0, m.Width*scale, m.Height*scale))
+	for y := 0; y < m.Height*scale; y++ {
+		for x := 0; x < m.Width*scale; x++ {
+			p := NewPoint(x/scale, y/scale)
+			_, explored := o.Explored[p]
+			c := OPEN_COLOR
+			switch {
+			case m.Walls.IsWall(p):
+				c = WALL_COLOR
+			case path[p]:
+				c = PATH_COLOR
+			case explored:
+				c = EXPLORED_COLOR
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func (m *Maze) WritePNG(w io.Writer, o Overlay, scale int) error {
+	return png.Encode(w, m.Image(o, scale))
+}
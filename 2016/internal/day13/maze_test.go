@@ -0,0 +1,134 @@
+package day13
+
+import (
+	"bytes"
+	"image/color"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	m := NewMaze(NewMapGenerator(10), 10, 7)
+	var b bytes.Buffer
+	if err := m.Render(&b, Overlay{}); err != nil {
+		t.Fatalf("Render() = error '%v'", err)
+	}
+	expected := `  0123456789
+0 .#.####.##
+1 ..#..#...#
+2 #....##...
+3 ###.#.###.
+4 .##..#..#.
+5 ..##....#.
+6 #...##.###
+`
+	if b.String() != expected {
+		t.Errorf("Render() = \n%s\nwant\n%s", b.String(), expected)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	m := NewMaze(NewMapGenerator(10), 20, 20)
+	tests := []struct {
+		from     Point
+		to       Point
+		expected int
+	}{
+		{from: NewPoint(1, 1), to: NewPoint(7, 4), expected: 11},
+		{from: NewPoint(7, 4), to: NewPoint(1, 1), expected: 11},
+		{from: NewPoint(1, 1), to: NewPoint(1, 1), expected: 0},
+		// A wall
+		{from: NewPoint(1, 1), to: NewPoint(1, 0), expected: -1},
+		// Outside of the region
+		{from: NewPoint(1, 1), to: NewPoint(20, 0), expected: -1},
+	}
+
+	for _, test := range tests {
+		path := m.ShortestPath(test.from, test.to)
+		if len(path)-1 != test.expected {
+			t.Errorf("ShortestPath(%v, %v) = %d steps, want %d", test.from, test.to, len(path)-1, test.expected)
+			continue
+		}
+		for i, p := range path {
+			if !m.Open(p) || i > 0 && p.Distance(path[i-1]) != 1 {
+				t.Errorf("ShortestPath(%v, %v) = %v, invalid step to %v", test.from, test.to, path, p)
+			}
+		}
+	}
+}
+
+func TestReachable(t *testing.T) {
+	m := NewMaze(NewMapGenerator(10), 10, 7)
+	result := m.Reachable(NewPoint(1, 1), 1)
+	expected := map[Point]int{NewPoint(1, 1): 0, NewPoint(0, 1): 1, NewPoint(1, 2): 1}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Reachable((1, 1), 1) = %v, want %v", result, expected)
+	}
+	// The open points on the right are cut from the rest by the region
+	if size := len(m.Component(NewPoint(7, 0))); size != 10 {
+		t.Errorf("Component((7, 0)) = %d points, want 10", size)
+	}
+	if size := len(m.Component(NewPoint(1, 0))); size != 0 {
+		t.Errorf("Component((1, 0)) = %d points, want 0", size)
+	}
+}
+
+func TestImage(t *testing.T) {
+	m := NewMaze(NewMapGenerator(10), 10, 7)
+	path := m.ShortestPath(NewPoint(1, 1), NewPoint(7, 4))
+	img := m.Image(Overlay{Path: path}, 3)
+	if b := img.Bounds(); b.Dx() != 30 || b.Dy() != 21 {
+		t.Errorf("Image() = %v, want 30x21", b)
+	}
+	tests := []struct {
+		x, y     int
+		expected color.Color
+	}{
+		{x: 4, y: 1, expected: WALL_COLOR},
+		{x: 4, y: 4, expected: PATH_COLOR},
+		{x: 1, y: 1, expected: OPEN_COLOR},
+	}
+	for _, test := range tests {
+		if c := img.At(test.x, test.y); c != test.expected {
+			t.Errorf("Image().At(%d, %d) = %v, want %v", test.x, test.y, c, test.expected)
+		}
+	}
+}
+
+// Every algorithm must find as many steps as a plain BFS.
+func TestAlgorithmsRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(13, 2016))
+	for n := 0; n < 20; n++ {
+		m := NewMaze(NewMapGenerator(r.IntN(10000)), 40, 40)
+		from := NewPoint(1, 1)
+		if !m.Open(from) {
+			continue
+		}
+		var targets []Point
+		for len(targets) < 10 {
+			if p := NewPoint(r.IntN(m.Width), r.IntN(m.Height)); m.Open(p) {
+				targets = append(targets, p)
+			}
+		}
+		distances := m.Distances(from, targets)
+		for i, to := range targets {
+			expected := m.Search(from, to, BFS)
+			if distances[i] != len(expected.Path)-1 {
+				t.Errorf("Distances(%v)[%v] = %d with seed %v, want %d", from, to, distances[i], m.Walls, len(expected.Path)-1)
+			}
+			for _, algorithm := range []Algorithm{ASTAR, BIDIRECTIONAL} {
+				result := m.Search(from, to, algorithm)
+				if result.Found != expected.Found || result.Cost != expected.Cost {
+					t.Errorf("Search(%v, %v, %d) = %d steps with seed %v, want %d", from, to, algorithm, result.Cost, m.Walls, expected.Cost)
+					continue
+				}
+				for k, p := range result.Path {
+					if !m.Open(p) || k > 0 && p.Distance(result.Path[k-1]) != 1 {
+						t.Errorf("Search(%v, %v, %d) = %v, invalid step to %v", from, to, algorithm, result.Path, p)
+					}
+				}
+			}
+		}
+	}
+}
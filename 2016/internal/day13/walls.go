@@ -0,0 +1,71 @@
+package day13
+
+import (
+	"fmt"
+	"strings"
+
+	"aoc2016/internal/utils"
+)
+
+// WallFunc tells the walls of a maze, points with negative coordinates are
+// never asked.
+type WallFunc interface {
+	IsWall(p Point) bool
+}
+
+// Neighbors calls yield for the open points next to p.
+func Neighbors(w WallFunc, p Point, yield func(next Point, cost int)) {
+	for k := -1; k <= 1; k += 2 {
+		for _, next := range []Point{NewPoint(p.X+k, p.Y), NewPoint(p.X, p.Y+k)} {
+			if next.X >= 0 && next.Y >= 0 && !w.IsWall(next) {
+				yield(next, 1)
+			}
+		}
+	}
+}
+
+// Bitmap is a static maze drawn with # for walls, everything outside of it
+// is a wall.
+type Bitmap struct {
+	rows  []string
+	width int
+}
+
+func ParseBitmap(content string) (Bitmap, error) {
+	var b Bitmap
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if len(line) == 0 {
+			continue
+		}
+		b.rows = append(b.rows, line)
+		b.width = max(b.width, len(line))
+	}
+	if len(b.rows) == 0 {
+		return b, fmt.Errorf("empty bitmap")
+	}
+	return b, nil
+}
+
+func LoadBitmap(filename string) (Bitmap, error) {
+	content, err := utils.ReadAllFile(filename)
+	if err != nil {
+		return Bitmap{}, err
+	}
+	return ParseBitmap(content)
+}
+
+func (b Bitmap) Width() int {
+	return b.width
+}
+
+func (b Bitmap) Height() int {
+	return len(b.rows)
+}
+
+func (b Bitmap) IsWall(p Point) bool {
+	if p.Y < 0 || p.Y >= len(b.rows) || p.X < 0 || p.X >= len(b.rows[p.Y]) {
+		return true
+	}
+	return b.rows[p.Y][p.X] == '#'
+}
@@ -0,0 +1,55 @@
+package day13
+
+import (
+	"testing"
+)
+
+func TestBitmap(t *testing.T) {
+	b, err := ParseBitmap(`#########
+#.......#
+#.#####.#
+#...#...#
+#########
+`)
+	if err != nil {
+		t.Fatalf("ParseBitmap() = error '%v'", err)
+	}
+	if b.Width() != 9 || b.Height() != 5 {
+		t.Errorf("ParseBitmap() = %dx%d, want 9x5", b.Width(), b.Height())
+	}
+	m := NewMaze(b, 20, 20)
+	tests := []struct {
+		from     Point
+		to       Point
+		expected int
+	}{
+		{from: NewPoint(1, 3), to: NewPoint(7, 3), expected: 10},
+		{from: NewPoint(1, 1), to: NewPoint(3, 3), expected: 4},
+		// Outside of the bitmap
+		{from: NewPoint(1, 1), to: NewPoint(10, 1), expected: -1},
+	}
+	for _, test := range tests {
+		if result := len(m.ShortestPath(test.from, test.to)) - 1; result != test.expected {
+			t.Errorf("ShortestPath(%v, %v) = %d steps, want %d", test.from, test.to, result, test.expected)
+		}
+	}
+	if size := len(m.Component(NewPoint(1, 1))); size != 15 {
+		t.Errorf("Component((1, 1)) = %d points, want 15", size)
+	}
+	if _, err := ParseBitmap("\n\n"); err == nil {
+		t.Errorf("ParseBitmap(empty) = no error")
+	}
+}
+
+func TestWallFuncs(t *testing.T) {
+	f, err := ParseFormula("x == 2 && y != 3", 0)
+	if err != nil {
+		t.Fatalf("ParseFormula() = error '%v'", err)
+	}
+	for _, w := range []WallFunc{NewMapGenerator(10), f} {
+		m := NewMaze(w, 10, 7)
+		if len(m.ShortestPath(NewPoint(1, 1), NewPoint(7, 4))) == 0 {
+			t.Errorf("ShortestPath(%T) = no path", w)
+		}
+	}
+}
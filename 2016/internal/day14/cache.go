@@ -0,0 +1,306 @@
+package day14
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var cacheDir string = ""
+
+// EnableCache keeps the hash patterns in the user cache directory between
+// runs.
+func EnableCache() {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	cacheDir = filepath.Join(dir, "aoc2016")
+}
+
+// Cache holds the patterns of the hashes of a salt for indices from 0, only
+// the first triple of each hash is kept.
+type Cache struct {
+	Salt     string
+	Stretch  int
+	Patterns []HashPattern
+	// Patterns were added since the cache was loaded or saved
+	dirty bool
+}
+
+// The file starts with CACHE_MAGIC and CACHE_VERSION, the stretch and the
+// salt. Then one byte per index, 0 without a triple or 0x10 plus the digit of
+// the first triple, and the list of the rare quintuples as their index
+// followed by their digit, the first quintuple of an index coming first.
+const (
+	CACHE_MAGIC   = "D14C"
+	CACHE_VERSION = 2
+)
+
+// The bit set in the byte of an index with a triple
+const TRIPLE_FLAG = 0x10
+
+func NewCache(salt string, stretch int) *Cache {
+	var c Cache
+	c.Salt = salt
+	c.Stretch = stretch
+	return &c
+}
+
+func CacheFile(dir, salt string, stretch int) string {
+	return filepath.Join(dir, fmt.Sprintf("day14-%s-%d.bin", hex.EncodeToString([]byte(salt)), stretch))
+}
+
+// LoadCache reads the cache of the salt from dir, it is empty when there is
+// no file yet.
+func LoadCache(dir, salt string, stretch int) (*Cache, error) {
+	file, err := os.Open(CacheFile(dir, salt, stretch))
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewCache(salt, stretch), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	c, err := ReadCache(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file.Name(), err)
+	}
+	if c.Salt != salt || c.Stretch != stretch {
+		return nil, fmt.Errorf("%s: cache of %q stretched %d times", file.Name(), c.Salt, c.Stretch)
+	}
+	return c, nil
+}
+
+// Save writes the cache in dir, through a temporary file so that an
+// interrupted run leaves the previous cache.
+func (c *Cache) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, "day14-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	w := bufio.NewWriter(file)
+	if err := c.Write(w); err != nil {
+		file.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), CacheFile(dir, c.Salt, c.Stretch)); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// Extend adds the patterns beyond the ones already cached.
+func (c *Cache) Extend(patterns []HashPattern) {
+	if len(patterns) > len(c.Patterns) {
+		c.Patterns = append(c.Patterns, patterns[len(c.Patterns):]...)
+		c.dirty = true
+	}
+}
+
+// Dirty tells if the cache has patterns that are not saved yet.
+func (c *Cache) Dirty() bool {
+	return c.dirty
+}
+
+func encodeDigit(ch int) (byte, error) {
+	if ch == -1 {
+		return 0, nil
+	}
+	i := strings.IndexByte(HEX, byte(ch))
+	if ch > 0xff || i < 0 {
+		return 0, fmt.Errorf("not a hexadecimal digit: %q", rune(ch))
+	}
+	return TRIPLE_FLAG | byte(i), nil
+}
+
+func decodeDigit(b byte) int {
+	if b&TRIPLE_FLAG == 0 {
+		return -1
+	}
+	return int(HEX[b&0x0f])
+}
+
+func (c *Cache) Write(w io.Writer) error {
+	if len(c.Salt) > math.MaxUint16 {
+		return fmt.Errorf("salt of %d bytes, want at most %d", len(c.Salt), math.MaxUint16)
+	}
+	if c.Stretch < 0 || int64(c.Stretch) > math.MaxUint32 {
+		return fmt.Errorf("invalid stretch: %d", c.Stretch)
+	}
+	if uint64(len(c.Patterns)) > math.MaxUint32 {
+		return fmt.Errorf("%d patterns, want at most %d", len(c.Patterns), uint32(math.MaxUint32))
+	}
+	header := []byte(CACHE_MAGIC)
+	header = append(header, CACHE_VERSION)
+	header = binary.LittleEndian.AppendUint32(header, uint32(c.Stretch))
+	header = binary.LittleEndian.AppendUint16(header, uint16(len(c.Salt)))
+	header = append(header, c.Salt...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(c.Patterns)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	triples := make([]byte, len(c.Patterns))
+	var quintuples []byte
+	count := 0
+	for i, p := range c.Patterns {
+		var err error
+		if triples[i], err = encodeDigit(p.Size3); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+		if p.Size5 == -1 {
+			continue
+		}
+		digits := []int{p.Size5}
+		for d := 0; d < len(HEX); d++ {
+			if p.Quintuples&(1<<d) != 0 && int(HEX[d]) != p.Size5 {
+				digits = append(digits, int(HEX[d]))
+			}
+		}
+		for _, ch := range digits {
+			digit, err := encodeDigit(ch)
+			if err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+			quintuples = binary.LittleEndian.AppendUint32(quintuples, uint32(i))
+			quintuples = append(quintuples, digit)
+			count++
+		}
+	}
+	if _, err := w.Write(triples); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint32(count)); err != nil {
+		return err
+	}
+	_, err := w.Write(quintuples)
+	return err
+}
+
+func ReadCache(r io.Reader) (*Cache, error) {
+	var header struct {
+		Magic   [len(CACHE_MAGIC)]byte
+		Version uint8
+		Stretch uint32
+		Salt    uint16
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if string(header.Magic[:]) != CACHE_MAGIC {
+		return nil, fmt.Errorf("not a day 14 cache")
+	}
+	if header.Version != CACHE_VERSION {
+		return nil, fmt.Errorf("unsupported cache version %d", header.Version)
+	}
+	salt := make([]byte, header.Salt)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return nil, err
+	}
+	c := NewCache(string(salt), int(header.Stretch))
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	// Read what the file has rather than trusting the count
+	triples, err := io.ReadAll(io.LimitReader(r, int64(count)))
+	if err != nil {
+		return nil, err
+	}
+	if len(triples) < int(count) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	c.Patterns = make([]HashPattern, count)
+	for i, b := range triples {
+		size3 := decodeDigit(b)
+		c.Patterns[i] = HashPattern{Size3: size3, Size5: -1, Triples: digitBit(size3)}
+	}
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	// An index has at most one quintuple per digit
+	if uint64(count) > uint64(len(HEX))*uint64(len(c.Patterns)) {
+		return nil, fmt.Errorf("%d quintuples for %d indices", count, len(c.Patterns))
+	}
+	for k := uint32(0); k < count; k++ {
+		var quintuple struct {
+			Index uint32
+			Digit byte
+		}
+		if err := binary.Read(r, binary.LittleEndian, &quintuple); err != nil {
+			return nil, err
+		}
+		if int(quintuple.Index) >= len(c.Patterns) {
+			return nil, fmt.Errorf("quintuple at index %d of %d", quintuple.Index, len(c.Patterns))
+		}
+		p := &c.Patterns[quintuple.Index]
+		size5 := decodeDigit(quintuple.Digit)
+		if p.Size5 == -1 {
+			p.Size5 = size5
+		}
+		p.Quintuples |= digitBit(size5)
+	}
+	return c, nil
+}
+
+// Quintuples keeps the indices of the quintuples of each digit in a window
+// of patterns, so that checking a key does not go through the whole window.
+type Quintuples struct {
+	queues [len(HEX)][]int
+}
+
+func NewQuintuples() Quintuples {
+	var q Quintuples
+	return q
+}
+
+// Add appends the quintuples of the next index of the window.
+func (q *Quintuples) Add(index int, quintuples uint16) {
+	for d := range q.queues {
+		if quintuples&(1<<d) != 0 {
+			q.queues[d] = append(q.queues[d], index)
+		}
+	}
+}
+
+// Remove drops the quintuples of the first index of the window.
+func (q *Quintuples) Remove(index int, quintuples uint16) {
+	for d := range q.queues {
+		if quintuples&(1<<d) != 0 && len(q.queues[d]) > 0 && q.queues[d][0] == index {
+			q.queues[d] = q.queues[d][1:]
+		}
+	}
+}
+
+// Confirmation returns the first index of the window with a quintuple of
+// one of the digits, -1 when there is none.
+func (q *Quintuples) Confirmation(digits uint16) int {
+	result := -1
+	for d := range q.queues {
+		if digits&(1<<d) != 0 && len(q.queues[d]) > 0 && (result == -1 || q.queues[d][0] < result) {
+			result = q.queues[d][0]
+		}
+	}
+	return result
+}
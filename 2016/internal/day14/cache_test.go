@@ -0,0 +1,160 @@
+package day14
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCacheWrite(t *testing.T) {
+	c := NewCache("abc", 2016)
+	c.Patterns = []HashPattern{
+		CollectHashPattern("0123"),
+		CollectHashPattern("0888"),
+		CollectHashPattern("eeeee"),
+		CollectHashPattern("000fffff11111"),
+	}
+	var buffer bytes.Buffer
+	if err := c.Write(&buffer); err != nil {
+		t.Fatalf("Cache.Write() = %v", err)
+	}
+	// Header, a byte per index and a quintuple list of 3 entries
+	if expected := 4 + 1 + 4 + 2 + 3 + 4 + 4 + 4 + 3*5; buffer.Len() != expected {
+		t.Errorf("Cache.Write() wrote %d bytes, want %d", buffer.Len(), expected)
+	}
+	result, err := ReadCache(&buffer)
+	if err != nil {
+		t.Fatalf("ReadCache() = %v", err)
+	}
+	// Only the first triple is kept
+	for i := range c.Patterns {
+		c.Patterns[i].Triples = digitBit(c.Patterns[i].Size3)
+	}
+	if !reflect.DeepEqual(result, c) {
+		t.Errorf("ReadCache() = %v, want %v", result, c)
+	}
+}
+
+func TestCacheWriteInvalid(t *testing.T) {
+	c := NewCache("abc", 0)
+	c.Patterns = []HashPattern{CollectHashPattern("xxx")}
+	var buffer bytes.Buffer
+	if err := c.Write(&buffer); err == nil {
+		t.Errorf("Cache.Write(xxx) = nil, want an error")
+	}
+}
+
+func TestCacheWriteOverflow(t *testing.T) {
+	tests := []*Cache{
+		NewCache(strings.Repeat("a", math.MaxUint16+1), 0),
+		NewCache("abc", -1),
+		NewCache("abc", math.MaxUint32+1),
+	}
+
+	for _, c := range tests {
+		var buffer bytes.Buffer
+		if err := c.Write(&buffer); err == nil {
+			t.Errorf("Cache.Write(%d bytes of salt, stretch %d) = nil, want an error", len(c.Salt), c.Stretch)
+		}
+	}
+}
+
+func TestReadCacheCorrupt(t *testing.T) {
+	var header bytes.Buffer
+	if err := NewCache("abc", 0).Write(&header); err != nil {
+		t.Fatalf("Cache.Write() = %v", err)
+	}
+	// Drop the empty pattern and quintuple counts
+	prefix := header.Bytes()[:header.Len()-8]
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "huge pattern count", data: binary.LittleEndian.AppendUint32(bytes.Clone(prefix), math.MaxUint32)},
+		{name: "huge quintuple count", data: binary.LittleEndian.AppendUint32(append(binary.LittleEndian.AppendUint32(bytes.Clone(prefix), 1), 0), math.MaxUint32)},
+	}
+
+	for _, test := range tests {
+		if _, err := ReadCache(bytes.NewReader(test.data)); err == nil {
+			t.Errorf("ReadCache(%s) = nil, want an error", test.name)
+		}
+	}
+}
+
+func TestLoadCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := LoadCache(dir, "abc", 0)
+	if err != nil || len(c.Patterns) != 0 {
+		t.Fatalf("LoadCache(empty) = (%v, %v), want an empty cache", c, err)
+	}
+	os.WriteFile(CacheFile(dir, "abc", 0), []byte("nope"), 0o644)
+	if _, err := LoadCache(dir, "abc", 0); err == nil {
+		t.Errorf("LoadCache(nope) = nil, want an error")
+	}
+}
+
+func TestHashFinderCache(t *testing.T) {
+	dir := t.TempDir()
+	for i, parallel := range []bool{false, true, false} {
+		c, err := LoadCache(dir, "abc", 0)
+		if err != nil {
+			t.Fatalf("LoadCache() = %v", err)
+		}
+		f := NewHashFinder("abc", 0)
+		f.UseCache(c)
+		if parallel {
+			f.RunParallel()
+		} else {
+			f.RunSequencial()
+		}
+		if f.index != 22728 {
+			t.Errorf("HashFinder(abc, parallel %v) = %v, want %v", parallel, f.index, 22728)
+		}
+		if len(c.Patterns) != 22728+WINDOW+1 {
+			t.Errorf("len(Cache.Patterns) = %v, want %v", len(c.Patterns), 22728+WINDOW+1)
+		}
+		// Only the first run adds patterns
+		if c.Dirty() != (i == 0) {
+			t.Errorf("Cache.Dirty() = %v after run %d", c.Dirty(), i+1)
+		}
+		if err := c.Save(dir); err != nil {
+			t.Fatalf("Cache.Save() = %v", err)
+		}
+	}
+}
+
+func TestQuintuples(t *testing.T) {
+	q := NewQuintuples()
+	a := digitBit(int('a'))
+	b := digitBit(int('b'))
+	masks := []uint16{0, b, a | b, a}
+	for i, mask := range masks {
+		q.Add(i, mask)
+	}
+	tests := []struct {
+		remove   int
+		digits   uint16
+		expected int
+	}{
+		{remove: -1, digits: a, expected: 2},
+		{remove: -1, digits: a | b, expected: 1},
+		{remove: -1, digits: digitBit(int('c')), expected: -1},
+		{remove: 1, digits: b, expected: 2},
+		{remove: 2, digits: b, expected: -1},
+		{remove: 2, digits: a | b, expected: 3},
+	}
+
+	for _, test := range tests {
+		if test.remove >= 0 {
+			q.Remove(test.remove, masks[test.remove])
+		}
+		result := q.Confirmation(test.digits)
+		if result != test.expected {
+			t.Errorf("Quintuples.Confirmation(%b) = %v, want %v", test.digits, result, test.expected)
+		}
+	}
+}
@@ -1,18 +1,15 @@
 package day14
 
 import (
-	"crypto/md5"
-	"encoding/hex"
+	"context"
 	"fmt"
-	"io"
-	"runtime"
 	"strings"
-	"sync"
 
+	"aoc2016/internal/md5mine"
 	"aoc2016/internal/utils"
 )
 
-func parseFile(filename string) (string, error) {
+func ParseFile(filename string) (string, error) {
 	content, err := utils.ReadAllFile(filename)
 	if err != nil {
 		return "", err
@@ -21,188 +18,255 @@ func parseFile(filename string) (string, error) {
 	return content, err
 }
 
-func MD5Hash1(text string) string {
-	hasher := md5.New()
-	_, err := io.WriteString(hasher, text)
-	if err != nil {
-		panic(err)
-	}
-	return hex.EncodeToString(hasher.Sum(nil))
-}
-
 func MD5Hash(text string, stretch int) string {
-	hash := MD5Hash1(text)
-	for i := 0; i < stretch; i++ {
-		hash = MD5Hash1(hash)
-	}
-	return hash
+	hash := md5mine.Sum(text, stretch)
+	return hash.Hex()
 }
 
+// HashPattern is the first character repeated 3 and 5 times in a row, -1
+// when there is none, and the hexadecimal digits of every such run with bit
+// i for HEX[i].
 type HashPattern struct {
-	Size3 int
-	Size5 int
+	Size3      int
+	Size5      int
+	Triples    uint16
+	Quintuples uint16
 }
 
 func CollectHashPattern(hash string) HashPattern {
-	size3 := -1
-	size5 := -1
-	count := 1
+	return collectPattern(len(hash), 3, 5, func(i int) rune { return rune(hash[i]) })
+}
+
+const HEX = "0123456789abcdef"
+
+// The bit of a hexadecimal digit in the masks of HashPattern, 0 for the
+// other characters
+func digitBit(ch int) uint16 {
+	if ch < 0 || ch > 0xff {
+		return 0
+	}
+	i := strings.IndexByte(HEX, byte(ch))
+	if i < 0 {
+		return 0
+	}
+	return 1 << i
+}
+
+// collectPattern finds the runs of short and long characters, Size3 and
+// Size5 are the first ones whatever their length.
+func collectPattern(n int, short int, long int, at func(i int) rune) HashPattern {
+	p := HashPattern{Size3: -1, Size5: -1}
+	count := 0
 	prev := '?'
-	for _, ch := range hash {
-		if ch == prev {
+	for i := 0; i < n; i++ {
+		ch := at(i)
+		if i > 0 && ch == prev {
 			count++
 		} else {
 			count = 1
 		}
-		if count == 3 && size3 == -1 {
-			size3 = int(ch)
-		}
-		if count == 5 && size5 == -1 {
-			size5 = int(ch)
+		if count == short {
+			if p.Size3 == -1 {
+				p.Size3 = int(ch)
+			}
+			p.Triples |= digitBit(int(ch))
 		}
-		if size3 > 0 && size5 > 0 {
-			break
+		if count == long {
+			if p.Size5 == -1 {
+				p.Size5 = int(ch)
+			}
+			p.Quintuples |= digitBit(int(ch))
 		}
 		prev = ch
 	}
-	var p HashPattern
-	p.Size3 = size3
-	p.Size5 = size5
 	return p
 }
 
-func NextHashPattern(salt string, count int, stretch int) HashPattern {
-	input := fmt.Sprintf("%s%d", salt, count)
-	hash := MD5Hash(input, stretch)
-	return CollectHashPattern(hash)
-}
-
 type HashFinder struct {
-	salt    string
-	hs      []HashPattern
-	count   int
-	index   int
-	stretch int
+	salt   string
+	hs     []HashPattern
+	count  int
+	index  int
+	config Config
+	cache  *Cache
+	keys   []Key
 }
 
 func NewHashFinder(salt string, stretch int) HashFinder {
+	f, _ := NewConfiguredHashFinder(salt, PuzzleConfig(stretch))
+	return f
+}
+
+func NewConfiguredHashFinder(salt string, c Config) (HashFinder, error) {
 	var f HashFinder
+	if err := c.Validate(); err != nil {
+		return f, err
+	}
 	f.salt = salt
-	f.hs = make([]HashPattern, 0, 1001)
+	f.hs = make([]HashPattern, 0, c.Window+1)
 	f.count = 0
 	f.index = 0
-	f.stretch = stretch
-	return f
+	f.config = c
+	return f, nil
+}
+
+// UseCache takes the patterns from the cache instead of hashing while it
+// has them, and adds the new ones to it. The cache is ignored unless the
+// finder looks for the first triple and quintuples of MD5 hashes.
+func (f *HashFinder) UseCache(c *Cache) {
+	f.cache = c
 }
 
 func (f *HashFinder) Next() {
-	h := NextHashPattern(f.salt, f.count, f.stretch)
+	var h HashPattern
+	if f.config.Hash == MD5 {
+		hash := md5mine.Hash(f.salt, f.count, f.config.Stretch)
+		h = f.collect(&hash)
+	} else {
+		hash := f.config.Hash.Hex(f.salt, f.count, f.config.Stretch)
+		h = collectPattern(len(hash), f.config.Triple, f.config.Quintuple, func(i int) rune { return rune(hash[i]) })
+	}
 	f.hs = append(f.hs, h)
 	f.count++
 }
 
-func HashMatch(h HashPattern, hs []HashPattern) bool {
-	if h.Size3 == -1 {
+func (f *HashFinder) collect(hash *md5mine.Digest) HashPattern {
+	return collectPattern(2*len(hash), f.config.Triple, f.config.Quintuple, func(i int) rune { return rune(HEX[hash.Nibble(i)]) })
+}
+
+func (f *HashFinder) nextCached() bool {
+	if f.cache == nil || !f.config.cacheable() || f.cache.Stretch != f.config.Stretch || f.count >= len(f.cache.Patterns) {
 		return false
 	}
-	for _, o := range hs {
-		if o.Size5 == h.Size3 {
-			return true
-		}
-	}
-	return false
-}
-
-func (f *HashFinder) ComputeParallel() {
-	var wg sync.WaitGroup
-	workers := runtime.NumCPU()
-	n := workers*1000 + 1000
-	gap := 100 // To avoid bounds checks, it must be a factor of `n`.
-	hs := make([]HashPattern, n)
-	start := make(chan int, workers)
-	for w := 0; w < workers; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for s := range start {
-				for i := 0; i < gap; i++ {
-					hs[s+i] = NextHashPattern(f.salt, f.count+s+i, f.stretch)
-				}
-			}
-		}()
-	}
-	for i := 0; i < n; i += gap {
-		start <- i
-	}
-	close(start)
-	wg.Wait()
-	if len(f.hs) == 0 {
-		f.hs = hs
-	} else {
-		f.hs = append(f.hs[len(f.hs)-1000:], hs...)
-	}
-	f.count += n
+	f.hs = append(f.hs, f.cache.Patterns[f.count])
+	f.count++
+	return true
 }
 
+// RunParallel hashes the indices ahead in parallel, they are received in
+// order so the search is the same as RunSequencial. Only MD5 is hashed in
+// parallel.
 func (f *HashFinder) RunParallel() {
-	matches := 0
-	for matches < 64 {
-		f.ComputeParallel()
-		for i := 0; i < len(f.hs)-1000; i++ {
-			f.index = f.count - len(f.hs) + i
-			curr := f.hs[i]
-			next := f.hs[i+1 : i+1000+1]
-			if HashMatch(curr, next) {
-				matches++
-				if matches == 64 {
-					break
-				}
-			}
-		}
+	if f.config.Hash != MD5 {
+		f.RunSequencial()
+		return
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var hits <-chan md5mine.Hit
+	f.run(func() {
+		if f.nextCached() {
+			return
+		}
+		// Only hash what the cache misses
+		if hits == nil {
+			hits = md5mine.Mine(ctx, md5mine.Options{Salt: f.salt, Start: f.count, Stretch: f.config.Stretch})
+		}
+		hit := <-hits
+		f.hs = append(f.hs, f.collect(&hit.Digest))
+		f.count++
+	})
 }
 
 func (f *HashFinder) RunSequencial() {
-	for i := 0; i < 1000; i++ {
-		f.Next()
-	}
-	matches := 0
-	for matches < 64 {
-		f.Next()
-		curr := f.hs[f.index]
-		next := f.hs[f.index+1 : f.index+1000+1]
-		if HashMatch(curr, next) {
-			matches++
+	f.run(func() {
+		if !f.nextCached() {
+			f.Next()
+		}
+	})
+}
+
+// The number of hashes after a key where its quintuple is looked for
+const WINDOW = 1000
+
+// The digits of the runs of h that make it a key when confirmed
+func (f *HashFinder) candidates(h HashPattern) uint16 {
+	if f.config.AllTriples {
+		return h.Triples
+	}
+	return digitBit(h.Size3)
+}
+
+func (f *HashFinder) run(next func()) {
+	size := f.config.Window
+	for len(f.hs) <= f.index+size {
+		next()
+	}
+	// The quintuples of the hashes after the current index
+	window := NewQuintuples()
+	for i := f.index + 1; i <= f.index+size; i++ {
+		window.Add(i, f.hs[i].Quintuples)
+	}
+	for {
+		if confirmation := window.Confirmation(f.candidates(f.hs[f.index])); confirmation != -1 {
+			f.keys = append(f.keys, Key{Index: f.index, Confirmation: confirmation})
+			if len(f.keys) == f.config.Keys {
+				break
+			}
 		}
 		f.index++
+		if f.index >= f.config.limit() {
+			break
+		}
+		window.Remove(f.index, f.hs[f.index].Quintuples)
+		if len(f.hs) <= f.index+size {
+			next()
+		}
+		window.Add(f.index+size, f.hs[f.index+size].Quintuples)
+	}
+	if f.cache != nil && f.config.cacheable() {
+		f.cache.Extend(f.hs)
 	}
-	f.index--
+}
+
+// Keys returns the keys found by the last run, the index of the last one is
+// the answer of the puzzle.
+func (f *HashFinder) Keys() []Key {
+	return f.keys
 }
 
 const PARALLEL bool = true
 
-func part1(salt string) int {
-	f := NewHashFinder(salt, 0)
+func findIndex(salt string, stretch int) int {
+	f := NewHashFinder(salt, stretch)
+	var c *Cache
+	if cacheDir != "" {
+		var err error
+		c, err = LoadCache(cacheDir, salt, stretch)
+		if err != nil {
+			// Start over, the file is replaced once saved
+			fmt.Println(err)
+			c = NewCache(salt, stretch)
+		}
+		f.UseCache(c)
+	}
 	if PARALLEL {
 		f.RunParallel()
 	} else {
 		f.RunSequencial()
 	}
+	if c != nil && c.Dirty() {
+		if err := c.Save(cacheDir); err != nil {
+			fmt.Println(err)
+		}
+	}
+	if err := f.Err(); err != nil {
+		fmt.Println(err)
+		return -1
+	}
 	return f.index
 }
 
+func part1(salt string) int {
+	return findIndex(salt, 0)
+}
+
 func part2(salt string) int {
-	f := NewHashFinder(salt, 2016)
-	if PARALLEL {
-		f.RunParallel()
-	} else {
-		f.RunSequencial()
-	}
-	return f.index
+	return findIndex(salt, 2016)
 }
 
 func Solve() {
-	input, err := parseFile("./inputs/day-14.txt")
+	input, err := ParseFile("./inputs/day-14.txt")
 	if err != nil {
 		fmt.Println(err)
 		return
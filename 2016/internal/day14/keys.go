@@ -0,0 +1,168 @@
+package day14
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strconv"
+
+	"aoc2016/internal/md5mine"
+)
+
+type HashFunc int
+
+const (
+	MD5 HashFunc = iota
+	SHA1
+	SHA256
+)
+
+func (h HashFunc) String() string {
+	switch h {
+	case MD5:
+		return "md5"
+	case SHA1:
+		return "sha1"
+	case SHA256:
+		return "sha256"
+	}
+	return fmt.Sprintf("HashFunc(%d)", int(h))
+}
+
+func HashFuncFromString(text string) (HashFunc, error) {
+	for _, h := range []HashFunc{MD5, SHA1, SHA256} {
+		if h.String() == text {
+			return h, nil
+		}
+	}
+	return MD5, fmt.Errorf("invalid hash function: %s", text)
+}
+
+func (h HashFunc) newHash() hash.Hash {
+	if h == SHA1 {
+		return sha1.New()
+	}
+	return sha256.New()
+}
+
+// Size is the number of hexadecimal digits of a digest.
+func (h HashFunc) Size() int {
+	switch h {
+	case SHA1:
+		return 2 * sha1.Size
+	case SHA256:
+		return 2 * sha256.Size
+	}
+	return 2 * md5.Size
+}
+
+// Hex is the hexadecimal digest of the salt followed by the decimal index,
+// hashed again stretch times like MD5Hash.
+func (h HashFunc) Hex(salt string, index int, stretch int) string {
+	if h == MD5 {
+		d := md5mine.Hash(salt, index, stretch)
+		return d.Hex()
+	}
+	hh := h.newHash()
+	hh.Write([]byte(salt + strconv.Itoa(index)))
+	text := []byte(hex.EncodeToString(hh.Sum(nil)))
+	for i := 0; i < stretch; i++ {
+		hh.Reset()
+		hh.Write(text)
+		text = hex.AppendEncode(text[:0], hh.Sum(nil))
+	}
+	return string(text)
+}
+
+// Config tells which hashes make one-time pad keys: a hash with a run of
+// Triple characters is a key when one of the next Window hashes has a run
+// of Quintuple times the same character.
+type Config struct {
+	Keys      int
+	Window    int
+	Triple    int
+	Quintuple int
+	Stretch   int
+	Hash      HashFunc
+	// The number of hashes searched for the keys, MAX_HASHES when 0
+	Limit int
+	// Any run of Triple characters makes a candidate, not only the first one
+	AllTriples bool
+}
+
+// The bounds of a config, the patterns of every hash searched are kept
+const (
+	MAX_KEYS   = 1 << 16
+	MAX_WINDOW = 1 << 20
+	MAX_HASHES = 1 << 22
+)
+
+// PuzzleConfig is the config of the puzzle, 64 keys found with MD5.
+func PuzzleConfig(stretch int) Config {
+	return Config{Keys: 64, Window: WINDOW, Triple: 3, Quintuple: 5, Stretch: stretch, Hash: MD5}
+}
+
+func (c Config) Validate() error {
+	if c.Keys < 1 || c.Keys > MAX_KEYS {
+		return fmt.Errorf("invalid number of keys: %d, want 1 to %d", c.Keys, MAX_KEYS)
+	}
+	if c.Window < 1 || c.Window > MAX_WINDOW {
+		return fmt.Errorf("invalid window: %d, want 1 to %d", c.Window, MAX_WINDOW)
+	}
+	if c.Hash != MD5 && c.Hash != SHA1 && c.Hash != SHA256 {
+		return fmt.Errorf("invalid hash function: %v", c.Hash)
+	}
+	if c.Triple < 1 || c.Triple > c.Hash.Size() {
+		return fmt.Errorf("invalid run length: %d, want 1 to %d for %v", c.Triple, c.Hash.Size(), c.Hash)
+	}
+	if c.Quintuple < c.Triple || c.Quintuple > c.Hash.Size() {
+		return fmt.Errorf("invalid confirming run length: %d, want %d to %d for %v", c.Quintuple, c.Triple, c.Hash.Size(), c.Hash)
+	}
+	if c.Stretch < 0 {
+		return fmt.Errorf("invalid stretch: %d", c.Stretch)
+	}
+	if c.Limit < 0 || c.Limit > MAX_HASHES {
+		return fmt.Errorf("invalid number of hashes: %d, want up to %d", c.Limit, MAX_HASHES)
+	}
+	return nil
+}
+
+// The number of hashes searched for the keys
+func (c Config) limit() int {
+	if c.Limit == 0 {
+		return MAX_HASHES
+	}
+	return c.Limit
+}
+
+// The patterns of the cache are only found with these settings
+func (c Config) cacheable() bool {
+	return c.Hash == MD5 && c.Triple == 3 && c.Quintuple == 5 && !c.AllTriples
+}
+
+// Key is the index of a key and the index of the hash that confirms it.
+type Key struct {
+	Index        int
+	Confirmation int
+}
+
+// FindKeys returns the first keys of the salt in increasing order.
+func FindKeys(salt string, c Config) ([]Key, error) {
+	f, err := NewConfiguredHashFinder(salt, c)
+	if err != nil {
+		return nil, err
+	}
+	f.RunParallel()
+	return f.Keys(), f.Err()
+}
+
+// Err tells when the last run stopped at the limit before finding every key.
+func (f *HashFinder) Err() error {
+	if len(f.keys) < f.config.Keys {
+		return fmt.Errorf("found %d of %d keys in the first %d hashes", len(f.keys), f.config.Keys, f.config.limit())
+	}
+	return nil
+}
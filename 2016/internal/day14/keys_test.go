@@ -0,0 +1,128 @@
+package day14
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestHashFuncHex(t *testing.T) {
+	tests := []struct {
+		hash     HashFunc
+		stretch  int
+		expected string
+	}{
+		{hash: MD5, stretch: 0, expected: "577571be4de9dcce85a041ba0410f29f"},
+		{hash: MD5, stretch: 2016, expected: "a107ff634856bb300138cac6568c0f24"},
+		{hash: SHA1, stretch: 0, expected: "062c648aaf68174757c50ab1aeebb61e059c1d1b"},
+		{hash: SHA256, stretch: 0, expected: "56abfbd7d2ea606e667945422de5a368b8b0272b8f29081cb058b594dd7e3249"},
+	}
+
+	for _, test := range tests {
+		result := test.hash.Hex("abc", 0, test.stretch)
+		if result != test.expected {
+			t.Errorf("%v.Hex(abc, 0, %v) = %v, want %v", test.hash, test.stretch, result, test.expected)
+		}
+	}
+}
+
+// The keys found by checking every hash of the window
+func simulateKeys(salt string, c Config) []Key {
+	var hashes []string
+	hash := func(i int) string {
+		for len(hashes) <= i {
+			hashes = append(hashes, c.Hash.Hex(salt, len(hashes), c.Stretch))
+		}
+		return hashes[i]
+	}
+	var result []Key
+	for i := 0; len(result) < c.Keys; i++ {
+		h := hash(i)
+		p := collectPattern(len(h), c.Triple, c.Quintuple, func(k int) rune { return rune(h[k]) })
+		digits := digitBit(p.Size3)
+		if c.AllTriples {
+			digits = p.Triples
+		}
+		for j := i + 1; j <= i+c.Window && digits != 0; j++ {
+			o := hash(j)
+			q := collectPattern(len(o), c.Triple, c.Quintuple, func(k int) rune { return rune(o[k]) })
+			if q.Quintuples&digits != 0 {
+				result = append(result, Key{Index: i, Confirmation: j})
+				break
+			}
+		}
+	}
+	return result
+}
+
+func TestFindKeys(t *testing.T) {
+	tests := []Config{
+		PuzzleConfig(0),
+		{Keys: 10, Window: 1000, Triple: 3, Quintuple: 5, Hash: SHA1},
+		{Keys: 10, Window: 1000, Triple: 3, Quintuple: 5, Hash: SHA256},
+		{Keys: 20, Window: 100, Triple: 2, Quintuple: 4, Hash: MD5},
+		{Keys: 20, Window: 100, Triple: 2, Quintuple: 4, Hash: MD5, AllTriples: true},
+		{Keys: 5, Window: 200, Triple: 3, Quintuple: 4, Stretch: 3, Hash: SHA1},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			result, err := FindKeys("abc", test)
+			if err != nil {
+				t.Fatalf("FindKeys(%+v) = %v", test, err)
+			}
+			expected := simulateKeys("abc", test)
+			if !slices.Equal(result, expected) {
+				t.Errorf("FindKeys(%+v) = %v, want %v", test, result, expected)
+			}
+		})
+	}
+}
+
+func TestFindKeysPuzzle(t *testing.T) {
+	result, err := FindKeys("abc", PuzzleConfig(0))
+	if err != nil {
+		t.Fatalf("FindKeys(abc) = %v", err)
+	}
+	if result[0] != (Key{Index: 39, Confirmation: 816}) {
+		t.Errorf("FindKeys(abc)[0] = %v, want %v", result[0], Key{Index: 39, Confirmation: 816})
+	}
+	if last := result[len(result)-1].Index; len(result) != 64 || last != 22728 {
+		t.Errorf("FindKeys(abc) = %d keys up to %d, want 64 keys up to %d", len(result), last, 22728)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []Config{
+		{Keys: 0, Window: 1000, Triple: 3, Quintuple: 5},
+		{Keys: 64, Window: 0, Triple: 3, Quintuple: 5},
+		{Keys: 64, Window: 1000, Triple: 0, Quintuple: 5},
+		{Keys: 64, Window: 1000, Triple: 3, Quintuple: 5, Stretch: -1},
+		{Keys: 64, Window: 1000, Triple: 3, Quintuple: 5, Hash: HashFunc(7)},
+		{Keys: MAX_KEYS + 1, Window: 1000, Triple: 3, Quintuple: 5},
+		{Keys: 64, Window: MAX_WINDOW + 1, Triple: 3, Quintuple: 5},
+		{Keys: 64, Window: 1000, Triple: 40, Quintuple: 40},
+		{Keys: 64, Window: 1000, Triple: 3, Quintuple: 33},
+		{Keys: 64, Window: 1000, Triple: 3, Quintuple: 65, Hash: SHA256},
+		{Keys: 64, Window: 1000, Triple: 5, Quintuple: 3},
+		{Keys: 64, Window: 1000, Triple: 3, Quintuple: 5, Limit: -1},
+		{Keys: 64, Window: 1000, Triple: 3, Quintuple: 5, Limit: MAX_HASHES + 1},
+	}
+
+	for _, test := range tests {
+		if err := test.Validate(); err == nil {
+			t.Errorf("Config(%+v).Validate() = nil, want an error", test)
+		}
+	}
+}
+
+func TestFindKeysLimit(t *testing.T) {
+	c := Config{Keys: 1, Window: 10, Triple: 32, Quintuple: 32, Hash: MD5, Limit: 1000}
+	if result, err := FindKeys("abc", c); err == nil {
+		t.Errorf("FindKeys(%+v) = %v, want an error", c, result)
+	}
+	c = Config{Keys: 1, Window: 10, Triple: 40, Quintuple: 40, Hash: SHA1, Limit: 1000}
+	if result, err := FindKeys("abc", c); err == nil {
+		t.Errorf("FindKeys(%+v) = %v, want an error", c, result)
+	}
+}
@@ -0,0 +1,168 @@
+package day15
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Congruence is the set of times t with t % Modulus == Remainder.
+type Congruence struct {
+	Remainder int
+	Modulus   int
+}
+
+// Congruence returns the drop times that let the capsule through the disc
+// when it is the i-th one reached, i+1 seconds after the drop.
+func (d Disc) Congruence(i int) Congruence {
+	// At the drop time t the disc reaches Position + t + i + 1 - Time
+	offset := (d.Position+i+1-d.Time)%d.Positions + d.Positions
+	return Congruence{Remainder: (d.Positions - offset%d.Positions) % d.Positions, Modulus: d.Positions}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// mulMod computes a*b % m without overflow, for a and b lower than m.
+func mulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	_, rem := bits.Div64(hi, lo, uint64(m))
+	return int(rem)
+}
+
+// inverse returns x with a*x % m == 1, for a and m coprime.
+func inverse(a, m int) int {
+	// Extended Euclid on (a, m), only the coefficient of a is needed
+	x, nextX := 0, 1
+	r, nextR := m, a%m
+	for nextR != 0 {
+		q := r / nextR
+		x, nextX = nextX, x-q*nextX
+		r, nextR = nextR, r-q*nextR
+	}
+	if x < 0 {
+		x += m
+	}
+	return x
+}
+
+// merge returns the times of both congruences. ok is false when they have no
+// time in common, overflow is true when the period does not fit in an int.
+func (c Congruence) merge(o Congruence) (result Congruence, ok bool, overflow bool) {
+	g := gcd(c.Modulus, o.Modulus)
+	diff := o.Remainder - c.Remainder
+	if diff%g != 0 {
+		return result, false, false
+	}
+	m := c.Modulus / g
+	n := o.Modulus / g
+	if m > math.MaxInt/o.Modulus {
+		return result, true, true
+	}
+	// c.Remainder + c.Modulus*k for k solving m*k = diff/g (mod n)
+	k := 0
+	if n > 1 {
+		k = mulMod(((diff/g)%n+n)%n, inverse(m%n, n), n)
+	}
+	result.Modulus = m * o.Modulus
+	result.Remainder = (c.Remainder + c.Modulus*k) % result.Modulus
+	return result, true, false
+}
+
+// Alignment is every drop time that lets the capsule through all the discs,
+// Time then every Period seconds.
+type Alignment struct {
+	Time   *big.Int
+	Period *big.Int
+}
+
+// NoSolutionError tells two discs that are never aligned together, the
+// capsule never goes through both of them.
+type NoSolutionError struct {
+	First  Disc
+	Second Disc
+}
+
+func (e *NoSolutionError) Error() string {
+	return fmt.Sprintf("disc #%d (%d positions) and disc #%d (%d positions) are never aligned together",
+		e.First.ID, e.First.Positions, e.Second.ID, e.Second.Positions)
+}
+
+// conflict finds the discs that cannot be aligned together. The congruences
+// have a common solution as soon as every pair of them has one.
+func conflict(ds []Disc) error {
+	for j := range ds {
+		for i := 0; i < j; i++ {
+			if _, ok, _ := ds[i].Congruence(i).merge(ds[j].Congruence(j)); !ok {
+				return &NoSolutionError{First: ds[i], Second: ds[j]}
+			}
+		}
+	}
+	return fmt.Errorf("discs never aligned together")
+}
+
+// Align solves the congruences of the discs with the chinese remainder
+// theorem, the positions counts need not be coprime. Integers are used until
+// the period grows too large for them.
+func Align(ds []Disc) (Alignment, error) {
+	for _, d := range ds {
+		if d.Positions < 1 {
+			return Alignment{}, fmt.Errorf("disc #%d has %d positions", d.ID, d.Positions)
+		}
+	}
+	c := Congruence{Remainder: 0, Modulus: 1}
+	for i, d := range ds {
+		next, ok, overflow := c.merge(d.Congruence(i))
+		if overflow {
+			return alignBig(ds, i, c)
+		}
+		if !ok {
+			return Alignment{}, conflict(ds)
+		}
+		c = next
+	}
+	return Alignment{Time: big.NewInt(int64(c.Remainder)), Period: big.NewInt(int64(c.Modulus))}, nil
+}
+
+// alignBig goes on with the discs from the i-th one with big integers, c
+// being the congruence of the discs before it.
+func alignBig(ds []Disc, i int, c Congruence) (Alignment, error) {
+	r := big.NewInt(int64(c.Remainder))
+	m := big.NewInt(int64(c.Modulus))
+	var g, x, diff, n, k big.Int
+	for ; i < len(ds); i++ {
+		o := ds[i].Congruence(i)
+		on := big.NewInt(int64(o.Modulus))
+		// x is the inverse of m/g modulo n/g
+		g.GCD(&x, nil, m, on)
+		diff.Sub(big.NewInt(int64(o.Remainder)), r)
+		if new(big.Int).Mod(&diff, &g).Sign() != 0 {
+			return Alignment{}, conflict(ds)
+		}
+		n.Quo(on, &g)
+		k.Quo(&diff, &g)
+		k.Mul(&k, &x)
+		k.Mod(&k, &n)
+		r.Add(r, k.Mul(&k, m))
+		m.Mul(m, &n)
+		r.Mod(r, m)
+	}
+	return Alignment{Time: r, Period: m}, nil
+}
+
+// AlignedTime is the first drop time of Align, as long as it fits in an int.
+func AlignedTime(ds []Disc) (int, error) {
+	a, err := Align(ds)
+	if err != nil {
+		return 0, err
+	}
+	if !a.Time.IsInt64() || a.Time.Int64() > math.MaxInt {
+		return 0, fmt.Errorf("first drop time %v too large", a.Time)
+	}
+	return int(a.Time.Int64()), nil
+}
@@ -0,0 +1,102 @@
+package day15
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// The first aligned time found second by second up to limit, -1 after it
+func simulate(ds []Disc, limit int) int {
+	rs := make([]Disc, len(ds))
+	for t := 0; t <= limit; t++ {
+		AdvanceTime(ds, rs, t)
+		if IsAligned(rs) {
+			return t
+		}
+	}
+	return -1
+}
+
+func TestAlignSimulation(t *testing.T) {
+	r := rand.New(rand.NewPCG(15, 2016))
+	for i := 0; i < 500; i++ {
+		ds := make([]Disc, 1+r.IntN(5))
+		period := 1
+		for k := range ds {
+			ds[k].ID = k + 1
+			ds[k].Positions = 1 + r.IntN(12)
+			ds[k].Position = r.IntN(ds[k].Positions)
+			period = period / gcd(period, ds[k].Positions) * ds[k].Positions
+		}
+		expected := simulate(ds, period)
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			result, err := AlignedTime(ds)
+			var noSolution *NoSolutionError
+			if expected == -1 {
+				if !errors.As(err, &noSolution) {
+					t.Errorf("AlignedTime(%v) = (%v, %v), want no solution", ds, result, err)
+				}
+				return
+			}
+			if err != nil || result != expected {
+				t.Errorf("AlignedTime(%v) = (%v, %v), want %v", ds, result, err, expected)
+			}
+		})
+	}
+}
+
+func TestAlignNoSolution(t *testing.T) {
+	ds := []Disc{
+		{ID: 1, Positions: 3, Position: 0},
+		{ID: 2, Positions: 4, Position: 0},
+		{ID: 3, Positions: 6, Position: 0},
+	}
+	_, err := Align(ds)
+	var noSolution *NoSolutionError
+	if !errors.As(err, &noSolution) || noSolution.First.ID != 1 || noSolution.Second.ID != 3 {
+		t.Errorf("Align(%v) = %v, want discs #1 and #3 never aligned", ds, err)
+	}
+}
+
+func TestAlignBig(t *testing.T) {
+	primes := []int{1000000007, 1000000009, 998244353, 2147483647, 1000000021}
+	ds := make([]Disc, len(primes))
+	for i, p := range primes {
+		ds[i] = Disc{ID: i + 1, Positions: p, Position: p / (i + 2), Time: i}
+	}
+	a, err := Align(ds)
+	if err != nil {
+		t.Fatalf("Align() = %v", err)
+	}
+	period := big.NewInt(1)
+	for i, d := range ds {
+		period.Mul(period, big.NewInt(int64(d.Positions)))
+		// Position + t + i + 1 - Time
+		position := new(big.Int).Add(a.Time, big.NewInt(int64(d.Position+i+1-d.Time)))
+		if position.Mod(position, big.NewInt(int64(d.Positions))).Sign() != 0 {
+			t.Errorf("Align() = %v, disc #%d at position %v", a.Time, d.ID, position)
+		}
+	}
+	if a.Period.Cmp(period) != 0 || a.Time.Sign() < 0 || a.Time.Cmp(period) >= 0 {
+		t.Errorf("Align() = (%v, %v), want a time below the period %v", a.Time, a.Period, period)
+	}
+	if _, err := AlignedTime(ds); (err == nil) != a.Time.IsInt64() {
+		t.Errorf("AlignedTime() = %v for the time %v", err, a.Time)
+	}
+}
+
+func TestAlignPuzzle(t *testing.T) {
+	ds, err := ParseFile("../../inputs/day-15.txt")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, d := range [][]Disc{ds, append(ds, Disc{ID: len(ds) + 1, Positions: 11})} {
+		result, err := AlignedTime(d)
+		if expected := findAlignedTime(d); err != nil || result != expected {
+			t.Errorf("AlignedTime(%v) = (%v, %v), want %v", d, result, err, expected)
+		}
+	}
+}
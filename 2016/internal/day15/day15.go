@@ -61,7 +61,7 @@ func parseContent(content string) ([]Disc, error) {
 	return p.ParseLines(content)
 }
 
-func parseFile(filename string) ([]Disc, error) {
+func ParseFile(filename string) ([]Disc, error) {
 	content, err := utils.ReadAllFile(filename)
 	if err != nil {
 		return nil, err
@@ -85,6 +85,8 @@ func IsAligned(ds []Disc) bool {
 	return true
 }
 
+// findAlignedTime simulates the discs second by second, Align finds the
+// time without going through all of them.
 func findAlignedTime(ds []Disc) int {
 	rs := make([]Disc, len(ds))
 	copy(rs, ds)
@@ -95,8 +97,17 @@ func findAlignedTime(ds []Disc) int {
 	return t - 1
 }
 
+func alignedTime(ds []Disc) int {
+	t, err := AlignedTime(ds)
+	if err != nil {
+		fmt.Println(err)
+		return -1
+	}
+	return t
+}
+
 func part1(ds []Disc) int {
-	return findAlignedTime(ds)
+	return alignedTime(ds)
 }
 
 func part2(ds []Disc) int {
@@ -104,14 +115,13 @@ func part2(ds []Disc) int {
 		ID:        len(ds) + 1,
 		Time:      0,
 		Position:  0,
-		Positions: 11,
+		Positions: EXTRA_POSITIONS,
 	}
-	ds = append(ds, extra)
-	return findAlignedTime(ds)
+	return alignedTime(WithDisc(ds, extra))
 }
 
 func Solve() {
-	input, err := parseFile("./inputs/day-15.txt")
+	input, err := ParseFile("./inputs/day-15.txt")
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -0,0 +1,139 @@
+package day15
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The positions of the disc found below the others in part 2
+const EXTRA_POSITIONS = 11
+
+// PositionAt returns the position of the disc at time t.
+func (d Disc) PositionAt(t int) int {
+	return ((d.Position+t-d.Time)%d.Positions + d.Positions) % d.Positions
+}
+
+// ParseDiscSpec reads a disc written as positions/position, at position at
+// time 0.
+func ParseDiscSpec(text string) (Disc, error) {
+	var d Disc
+	positions, position, found := strings.Cut(text, "/")
+	if !found {
+		return d, fmt.Errorf("invalid disc %q, want positions/position", text)
+	}
+	var err error
+	if d.Positions, err = strconv.Atoi(positions); err != nil || d.Positions < 1 {
+		return d, fmt.Errorf("invalid number of positions in %q", text)
+	}
+	if d.Position, err = strconv.Atoi(position); err != nil || d.Position < 0 || d.Position >= d.Positions {
+		return d, fmt.Errorf("invalid position in %q", text)
+	}
+	return d, nil
+}
+
+// renumber gives the discs the ID of their place in the sculpture.
+func renumber(ds []Disc) []Disc {
+	for i := range ds {
+		ds[i].ID = i + 1
+	}
+	return ds
+}
+
+// WithDisc returns the discs with another one below them.
+func WithDisc(ds []Disc, d Disc) []Disc {
+	return renumber(append(slices.Clone(ds), d))
+}
+
+// WithoutDisc returns the discs without the one of the given ID, the discs
+// below it move up.
+func WithoutDisc(ds []Disc, id int) ([]Disc, error) {
+	i := slices.IndexFunc(ds, func(d Disc) bool { return d.ID == id })
+	if i < 0 {
+		return nil, fmt.Errorf("no disc #%d", id)
+	}
+	return renumber(slices.Delete(slices.Clone(ds), i, i+1)), nil
+}
+
+// Reconfigure returns the discs with the one of the given ID replaced.
+func Reconfigure(ds []Disc, id int, d Disc) ([]Disc, error) {
+	i := slices.IndexFunc(ds, func(d Disc) bool { return d.ID == id })
+	if i < 0 {
+		return nil, fmt.Errorf("no disc #%d", id)
+	}
+	ds = slices.Clone(ds)
+	d.ID = id
+	ds[i] = d
+	return ds, nil
+}
+
+// Timeline draws the position of every disc from time from to time to, one
+// column per second. The capsule dropped at time drop is drawn as v when
+// dropped, around the position of each disc it reaches, [0] when it goes
+// through and (n) when it bounces off, and as * once out of the sculpture.
+func Timeline(w io.Writer, ds []Disc, drop, from, to int) error {
+	// The disc the capsule bounces off, len(ds) when it goes through all
+	bottom := len(ds)
+	for i, d := range ds {
+		if d.PositionAt(drop+i+1) != 0 {
+			bottom = i
+			break
+		}
+	}
+
+	labels := []string{"time", "capsule"}
+	rows := [][]string{nil, nil}
+	for _, d := range ds {
+		labels = append(labels, fmt.Sprintf("#%d (%d)", d.ID, d.Positions))
+		rows = append(rows, nil)
+	}
+	labels = append(labels, "out")
+	rows = append(rows, nil)
+	for t := from; t <= to; t++ {
+		rows[0] = append(rows[0], strconv.Itoa(t))
+		capsule := ""
+		if t == drop {
+			capsule = "v"
+		}
+		rows[1] = append(rows[1], capsule)
+		for i, d := range ds {
+			text := strconv.Itoa(d.PositionAt(t))
+			switch {
+			case t != drop+i+1 || i > bottom:
+			case i == bottom:
+				text = "(" + text + ")"
+			default:
+				text = "[" + text + "]"
+			}
+			rows[i+2] = append(rows[i+2], text)
+		}
+		out := ""
+		if bottom == len(ds) && t == drop+len(ds)+1 {
+			out = "*"
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], out)
+	}
+
+	label := 0
+	for _, l := range labels {
+		label = max(label, len(l))
+	}
+	width := 0
+	for _, row := range rows {
+		for _, text := range row {
+			width = max(width, len(text))
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for k, row := range rows {
+		line := fmt.Sprintf("%-*s", label, labels[k])
+		for _, text := range row {
+			line += fmt.Sprintf(" %*s", width, text)
+		}
+		bw.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,77 @@
+package day15
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const example = `Disc #1 has 5 positions; at time=0, it is at position 4.
+Disc #2 has 2 positions; at time=0, it is at position 1.`
+
+func TestTimeline(t *testing.T) {
+	tests := []struct {
+		drop     int
+		from     int
+		to       int
+		expected string
+	}{
+		{drop: 5, from: 4, to: 8, expected: `time      4   5   6   7   8
+capsule       v
+#1 (5)    3   4 [0]   1   2
+#2 (2)    1   0   1 [0]   1
+out                       *
+`},
+		{drop: 0, from: 0, to: 3, expected: `time      0   1   2   3
+capsule   v
+#1 (5)    4 [0]   1   2
+#2 (2)    1   0 (1)   0
+out
+`},
+	}
+
+	ds, err := parseContent(example)
+	if err != nil {
+		t.Fatalf("parseContent(example) = %v", err)
+	}
+	for _, test := range tests {
+		var sb strings.Builder
+		if err := Timeline(&sb, ds, test.drop, test.from, test.to); err != nil {
+			t.Fatalf("Timeline(%v, %v, %v) = %v", test.drop, test.from, test.to, err)
+		}
+		if sb.String() != test.expected {
+			t.Errorf("Timeline(%v, %v, %v) = \n%s, want \n%s", test.drop, test.from, test.to, sb.String(), test.expected)
+		}
+	}
+}
+
+func TestWhatIf(t *testing.T) {
+	ds, err := parseContent(example)
+	if err != nil {
+		t.Fatalf("parseContent(example) = %v", err)
+	}
+	extra, err := ParseDiscSpec("11/0")
+	if err != nil {
+		t.Fatalf("ParseDiscSpec(11/0) = %v", err)
+	}
+	added := WithDisc(ds, extra)
+	if len(added) != 3 || added[2].ID != 3 || added[2].Positions != 11 {
+		t.Errorf("WithDisc(11/0) = %v", added)
+	}
+	removed, err := WithoutDisc(added, 1)
+	if err != nil || !slices.Equal(removed, []Disc{{ID: 1, Position: 1, Positions: 2}, {ID: 2, Positions: 11}}) {
+		t.Errorf("WithoutDisc(1) = (%v, %v)", removed, err)
+	}
+	changed, err := Reconfigure(ds, 2, Disc{Positions: 3, Position: 2})
+	if err != nil || changed[1] != (Disc{ID: 2, Positions: 3, Position: 2}) || ds[1].Positions != 2 {
+		t.Errorf("Reconfigure(2, 3/2) = (%v, %v)", changed, err)
+	}
+	if _, err := WithoutDisc(ds, 7); err == nil {
+		t.Errorf("WithoutDisc(7) = nil, want an error")
+	}
+	for _, spec := range []string{"11", "0/0", "5/5", "a/1"} {
+		if _, err := ParseDiscSpec(spec); err == nil {
+			t.Errorf("ParseDiscSpec(%v) = nil, want an error", spec)
+		}
+	}
+}
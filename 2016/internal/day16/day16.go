@@ -89,12 +89,21 @@ func (data Data) ChecksumWithSize(size int) Data {
 	return data.IncreaseUntilSize(size).Take(size).Checksum()
 }
 
+func dragonChecksum(data Data, size int) string {
+	result, err := data.DragonChecksum(size)
+	if err != nil {
+		fmt.Println(err)
+		return ""
+	}
+	return string(result)
+}
+
 func part1(data Data) string {
-	return string(data.ChecksumWithSize(272))
+	return dragonChecksum(data, 272)
 }
 
 func part2(data Data) string {
-	return string(data.ChecksumWithSize(35651584))
+	return dragonChecksum(data, 35651584)
 }
 
 func Solve() {
@@ -0,0 +1,89 @@
+package day16
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dragon counts the ones of the data grown from a seed without building it.
+// The data is the seed a, then its reversed complement b, a again and so
+// on, each followed by a joiner bit:
+//
+//	a d1 b d2 a d3 b d4 ...
+//
+// The joiners are the bits of the regular paperfolding sequence: writing k
+// as 2^j times an odd number o, dk is 1 when o%4 == 3.
+type Dragon struct {
+	size int
+	// Ones of a and b before each of their positions
+	onesA []int
+	onesB []int
+}
+
+func NewDragon(seed Data) Dragon {
+	var d Dragon
+	d.size = len(seed)
+	d.onesA = make([]int, len(seed)+1)
+	d.onesB = make([]int, len(seed)+1)
+	for i := 0; i < len(seed); i++ {
+		d.onesA[i+1] = d.onesA[i] + int(seed[i]-'0')
+		// b[i] is the complement of a[len(a)-1-i]
+		d.onesB[i+1] = d.onesB[i] + int('1'-seed[len(seed)-1-i])
+	}
+	return d
+}
+
+// joinerOnes counts the ones among the first m joiners: for each j, the odd
+// numbers o <= m>>j with o%4 == 3.
+func joinerOnes(m int) int {
+	result := 0
+	for ; m > 0; m >>= 1 {
+		result += (m + 1) / 4
+	}
+	return result
+}
+
+// Ones returns the number of ones among the first n bits of the data.
+func (d *Dragon) Ones(n int) int {
+	// Complete copies of a or b with their joiner, then part of the next one
+	m := n / (d.size + 1)
+	r := n % (d.size + 1)
+	result := (m+1)/2*d.onesA[d.size] + m/2*d.onesB[d.size] + joinerOnes(m)
+	if m%2 == 0 {
+		result += d.onesA[r]
+	} else {
+		result += d.onesB[r]
+	}
+	return result
+}
+
+// DragonChecksum is ChecksumWithSize in O(size) time without the data. Each
+// digit of the checksum reduces a chunk of 2^k bits, it is 1 when the chunk
+// has an even number of ones.
+func (data Data) DragonChecksum(size int) (Data, error) {
+	if size < 2 {
+		return Data(""), fmt.Errorf("invalid disk size: %d", size)
+	}
+	// The first pass drops the last bit of an odd size, then each pass halves
+	// the checksum while it is even
+	digits := size / 2
+	chunk := 2
+	for digits%2 == 0 {
+		digits /= 2
+		chunk *= 2
+	}
+	d := NewDragon(data)
+	var sb strings.Builder
+	sb.Grow(digits)
+	prev := 0
+	for i := 1; i <= digits; i++ {
+		ones := d.Ones(i * chunk)
+		if (ones-prev)%2 == 0 {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+		prev = ones
+	}
+	return Data(sb.String()), nil
+}
@@ -0,0 +1,75 @@
+package day16
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDragonChecksumExample(t *testing.T) {
+	result, err := Data("10000").DragonChecksum(20)
+	if err != nil {
+		t.Fatalf("DragonChecksum(10000, 20) = %v", err)
+	}
+	if result != "01100" {
+		t.Errorf("DragonChecksum(10000, 20) = %v, want %v", result, "01100")
+	}
+}
+
+func TestDragonChecksumSmall(t *testing.T) {
+	seeds := []Data{"1", "10000", "110010110100"}
+
+	for _, seed := range seeds {
+		for size := 2; size <= 64; size++ {
+			t.Run(fmt.Sprintf("%s/%d", seed, size), func(t *testing.T) {
+				expected := seed.ChecksumWithSize(size)
+				result, err := seed.DragonChecksum(size)
+				if err != nil {
+					t.Fatalf("DragonChecksum(%v, %v) = %v", seed, size, err)
+				}
+				if result != expected {
+					t.Errorf("DragonChecksum(%v, %v) = %v, want %v", seed, size, result, expected)
+				}
+			})
+		}
+	}
+}
+
+func TestDragonChecksumLarge(t *testing.T) {
+	// The size of part 2, checked against ChecksumWithSize
+	result, err := Data("10111100110001111").DragonChecksum(35651584)
+	if err != nil {
+		t.Fatalf("DragonChecksum(10111100110001111, 35651584) = %v", err)
+	}
+	if expected := Data("10001101010000101"); result != expected {
+		t.Errorf("DragonChecksum(10111100110001111, 35651584) = %v, want %v", result, expected)
+	}
+}
+
+func TestDragonChecksumSizes(t *testing.T) {
+	seeds := []Data{"0", "1", "10000", "111", "110010110100", "10111100110001111"}
+	// Odd sizes, sizes with odd factors and sizes below the length of a seed
+	sizes := []int{2, 3, 4, 5, 7, 10, 12, 20, 21, 24, 36, 100, 272, 1001, 3 << 10, 35651584 >> 10}
+
+	for _, seed := range seeds {
+		for _, size := range sizes {
+			t.Run(fmt.Sprintf("%s/%d", seed, size), func(t *testing.T) {
+				expected := seed.ChecksumWithSize(size)
+				result, err := seed.DragonChecksum(size)
+				if err != nil {
+					t.Fatalf("DragonChecksum(%v, %v) = %v", seed, size, err)
+				}
+				if result != expected {
+					t.Errorf("DragonChecksum(%v, %v) = %v, want %v", seed, size, result, expected)
+				}
+			})
+		}
+	}
+}
+
+func TestDragonChecksumInvalid(t *testing.T) {
+	for _, size := range []int{-1, 0, 1} {
+		if result, err := Data("10000").DragonChecksum(size); err == nil {
+			t.Errorf("DragonChecksum(10000, %v) = %v, want an error", size, result)
+		}
+	}
+}
@@ -1,22 +1,14 @@
 package day17
 
 import (
-	"crypto/md5"
-	"encoding/hex"
 	"fmt"
-	"io"
 	"strings"
 
+	"aoc2016/internal/md5mine"
+	"aoc2016/internal/search"
 	"aoc2016/internal/utils"
 )
 
-func MD5Hash(passcode string, path string) string {
-	hasher := md5.New()
-	io.WriteString(hasher, passcode)
-	io.WriteString(hasher, path)
-	return hex.EncodeToString(hasher.Sum(nil))
-}
-
 func parseFile(filename string) (string, error) {
 	content, err := utils.ReadAllFile(filename)
 	if err != nil {
@@ -26,101 +18,73 @@ func parseFile(filename string) (string, error) {
 	return content, nil
 }
 
-func isOpen(ch byte) bool {
-	return int('b') <= int(ch) && int(ch) <= int('f')
-}
-
-type PathFinder interface {
-	Decide(best, path string) string
-	Break(best, path string) bool
+// Doors are open for the hexadecimal digits b to f
+func isOpen(nibble byte) bool {
+	return 0xb <= nibble
 }
 
-type ShortestPathFinder struct{}
-
-func (pf ShortestPathFinder) Decide(best, path string) string {
-	if len(best) > 0 && len(best) < len(path) {
-		return best
-	} else {
-		return path
-	}
+type Room struct {
+	X    int
+	Y    int
+	Path string
 }
 
-func (pf ShortestPathFinder) Break(best, path string) bool {
-	return len(best) > 0 && len(path) >= len(best)
+func (r Room) Vault() bool {
+	return r.X == 3 && r.Y == 3
 }
 
-type LongestPathFinder struct{}
-
-func (pf LongestPathFinder) Decide(best, path string) string {
-	if len(best) > len(path) {
-		return best
-	} else {
-		return path
+// The doors depend on the path taken, so the path identifies the state
+func problem(passcode string) search.Problem[Room, string] {
+	return search.Problem[Room, string]{
+		Start: Room{},
+		Key:   func(r Room) string { return r.Path },
+		Neighbors: func(r Room, yield func(next Room, cost int)) {
+			if r.Vault() {
+				return
+			}
+			hash := md5mine.Sum(passcode+r.Path, 0)
+			if isOpen(hash.Nibble(0)) && r.Y > 0 {
+				yield(Room{X: r.X, Y: r.Y - 1, Path: r.Path + "U"}, 1)
+			}
+			if isOpen(hash.Nibble(1)) && r.Y < 3 {
+				yield(Room{X: r.X, Y: r.Y + 1, Path: r.Path + "D"}, 1)
+			}
+			if isOpen(hash.Nibble(2)) && r.X > 0 {
+				yield(Room{X: r.X - 1, Y: r.Y, Path: r.Path + "L"}, 1)
+			}
+			if isOpen(hash.Nibble(3)) && r.X < 3 {
+				yield(Room{X: r.X + 1, Y: r.Y, Path: r.Path + "R"}, 1)
+			}
+		},
+		Goal: Room.Vault,
 	}
 }
 
-func (pf LongestPathFinder) Break(best, path string) bool {
-	return false
-}
-
-func findPathRec(pf PathFinder, x, y int, passcode, path, best string) []string {
-	if !(0 <= x && x <= 3) || !(0 <= y && y <= 3) {
-		return nil
-	}
-	if x == 3 && y == 3 {
-		return []string{pf.Decide(best, path)}
-	}
-	if pf.Break(best, path) {
-		return nil
+func shortestPath(passcode string) string {
+	r := search.BFS(problem(passcode))
+	if !r.Found {
+		return ""
 	}
-	hash := MD5Hash(passcode, path)
-	if isOpen(hash[1]) /* down */ {
-		result := findPathRec(pf, x, y+1, passcode, path+"D", best)
-		if len(result) > 0 {
-			best = result[0]
-		}
-	}
-	if isOpen(hash[3]) /* right */ {
-		result := findPathRec(pf, x+1, y, passcode, path+"R", best)
-		if len(result) > 0 {
-			best = result[0]
-		}
-	}
-	if isOpen(hash[2]) /* left */ {
-		result := findPathRec(pf, x-1, y, passcode, path+"L", best)
-		if len(result) > 0 {
-			best = result[0]
-		}
-	}
-	if isOpen(hash[0]) /* up */ {
-		result := findPathRec(pf, x, y-1, passcode, path+"U", best)
-		if len(result) > 0 {
-			best = result[0]
-		}
-	}
-	if len(best) != 0 {
-		return []string{best}
-	}
-	return nil
+	return r.Path[len(r.Path)-1].Path
 }
 
-func findPath(pf PathFinder, passcode string) string {
-	result := findPathRec(pf, 0, 0, passcode, "", "")
-	if len(result) == 1 {
-		return result[0]
-	}
-	return ""
+func longestPathLength(passcode string) int {
+	longest := 0
+	search.Walk(problem(passcode), func(r Room, moves int) bool {
+		if r.Vault() {
+			longest = moves
+		}
+		return true
+	})
+	return longest
 }
 
 func part1(passcode string) string {
-	var shortest ShortestPathFinder
-	return findPath(shortest, passcode)
+	return shortestPath(passcode)
 }
 
 func part2(passcode string) int {
-	var longest LongestPathFinder
-	path := findPath(longest, passcode)
-	return len(path)
+	return longestPathLength(passcode)
 }
 
 func Solve() {
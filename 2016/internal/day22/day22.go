@@ -1,11 +1,11 @@
 package day22
 
 import (
-	"container/heap"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"aoc2016/internal/search"
 	"aoc2016/internal/utils"
 )
 
@@ -129,38 +129,6 @@ type Point struct {
 	X int
 }
 
-type Step struct {
-	Next Point
-	Dist int
-}
-
-func NewStep(next Point, dist int) Step {
-	return Step{Next: next, Dist: dist}
-}
-
-type PriorityQueue []Step
-
-func (pq PriorityQueue) Len() int {
-	return len(pq)
-}
-func (pq PriorityQueue) Less(i, j int) bool {
-	return pq[i].Dist <= pq[j].Dist
-}
-func (pq PriorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-}
-func (pq *PriorityQueue) Push(x any) {
-	item := x.(Step)
-	*pq = append(*pq, item)
-}
-func (pq *PriorityQueue) Pop() any {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	*pq = old[0 : n-1]
-	return item
-}
-
 func NewPoint(y, x int) Point {
 	return Point{Y: y, X: x}
 }
@@ -203,33 +171,20 @@ func MinDistance(g Grid, target Point) [][]int {
 			dist[i][j] = 1 << 32
 		}
 	}
-	dist[target.Y][target.X] = 0
-	pq := make(PriorityQueue, 1)
-	pq = append(pq, NewStep(target, 0))
-	for len(pq) > 0 {
-		curr := heap.Pop(&pq).(Step)
-		y := curr.Next.Y
-		x := curr.Next.X
-		if y > 0 && grid[y-1][x].Used <= grid[y][x].Size && dist[y][x]+1 < dist[y-1][x] {
-			next := NewStep(NewPoint(y-1, x), dist[y][x]+1)
-			dist[y-1][x] = dist[y][x] + 1
-			heap.Push(&pq, next)
-		}
-		if y < rows-1 && grid[y+1][x].Used <= grid[y][x].Size && dist[y][x]+1 < dist[y+1][x] {
-			next := NewStep(NewPoint(y+1, x), dist[y][x]+1)
-			dist[y+1][x] = dist[y][x] + 1
-			heap.Push(&pq, next)
-		}
-		if x > 0 && grid[y][x-1].Used <= grid[y][x].Size && dist[y][x]+1 < dist[y][x-1] {
-			next := NewStep(NewPoint(y, x-1), dist[y][x]+1)
-			dist[y][x-1] = dist[y][x] + 1
-			heap.Push(&pq, next)
-		}
-		if x < cols-1 && grid[y][x+1].Used <= grid[y][x].Size && dist[y][x]+1 < dist[y][x+1] {
-			next := NewStep(NewPoint(y, x+1), dist[y][x]+1)
-			dist[y][x+1] = dist[y][x] + 1
-			heap.Push(&pq, next)
-		}
+	p := search.Problem[Point, Point]{
+		Start: target,
+		Key:   func(p Point) Point { return p },
+		Neighbors: func(curr Point, yield func(next Point, cost int)) {
+			y, x := curr.Y, curr.X
+			for _, next := range []Point{NewPoint(y-1, x), NewPoint(y+1, x), NewPoint(y, x-1), NewPoint(y, x+1)} {
+				if 0 <= next.Y && next.Y < rows && 0 <= next.X && next.X < cols && grid[next.Y][next.X].Used <= grid[y][x].Size {
+					yield(next, 1)
+				}
+			}
+		},
+	}
+	for point, d := range search.Reachable(p, -1) {
+		dist[point.Y][point.X] = d
 	}
 	return dist
 }
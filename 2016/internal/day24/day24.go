@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"aoc2016/internal/search"
 	"aoc2016/internal/utils"
 )
 
 type Item struct {
-	X, Y     int
-	Wall     bool
-	Goal     int
-	Distance int
+	X, Y int
+	Wall bool
+	Goal int
 }
 
 type Map struct {
@@ -29,7 +29,7 @@ func parseContent(content string) (Map, error) {
 		cols := len(line)
 		row := make([]Item, cols)
 		for x := 0; x < cols; x++ {
-			item := Item{X: x, Y: y, Wall: true, Goal: -1, Distance: -1}
+			item := Item{X: x, Y: y, Wall: true, Goal: -1}
 			if x < len(line) && line[x] != '#' {
 				item.Wall = false
 				goal := int(line[x]) - int('0')
@@ -53,53 +53,39 @@ func parseFile(filename string) (Map, error) {
 	return parseContent(content)
 }
 
-func checkNextItem(item, next *Item, stack []*Item) []*Item {
-	if !next.Wall {
-		if next.Distance == -1 || next.Distance > item.Distance+1 {
-			next.Distance = item.Distance + 1
-			return append(stack, next)
+func (m *Map) Neighbors(item *Item, yield func(next *Item, cost int)) {
+	for _, d := range [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		x, y := item.X+d[0], item.Y+d[1]
+		if 0 <= y && y < m.rows && 0 <= x && x < len(m.grid[y]) && !m.grid[y][x].Wall {
+			yield(&m.grid[y][x], 1)
 		}
 	}
-	return stack
 }
 
 func calculateDistances(m Map, location int) []int {
-	stack := make([]*Item, 0, 4)
+	var start *Item
 	for y := range m.rows {
 		for x := range m.cols {
-			item := &m.grid[y][x]
-			item.Distance = -1
-			if item.Goal == location {
-				item.Distance = 0
-				stack = append(stack, item)
+			if m.grid[y][x].Goal == location {
+				start = &m.grid[y][x]
 			}
 		}
 	}
-	if len(stack) == 0 {
+	if start == nil {
 		return nil
 	}
-	for len(stack) > 0 {
-		item := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-		if item.Y > 0 {
-			stack = checkNextItem(item, &m.grid[item.Y-1][item.X], stack)
-		}
-		if item.Y < m.rows {
-			stack = checkNextItem(item, &m.grid[item.Y+1][item.X], stack)
-		}
-		if item.X > 0 {
-			stack = checkNextItem(item, &m.grid[item.Y][item.X-1], stack)
-		}
-		if item.X < m.cols {
-			stack = checkNextItem(item, &m.grid[item.Y][item.X+1], stack)
-		}
+	p := search.Problem[*Item, *Item]{
+		Start:     start,
+		Key:       func(item *Item) *Item { return item },
+		Neighbors: m.Neighbors,
 	}
 	distances := make([]int, 10)
-	for y := range m.rows {
-		for x := range m.cols {
-			if m.grid[y][x].Goal >= 0 {
-				distances[m.grid[y][x].Goal] = m.grid[y][x].Distance
-			}
+	for i := range distances {
+		distances[i] = -1
+	}
+	for item, d := range search.Reachable(p, -1) {
+		if item.Goal >= 0 {
+			distances[item.Goal] = d
 		}
 	}
 	return distances
@@ -0,0 +1,190 @@
+package md5mine
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"runtime"
+	"strconv"
+)
+
+type Digest [md5.Size]byte
+
+func (d *Digest) Hex() string {
+	return hex.EncodeToString(d[:])
+}
+
+// Nibble returns the i-th hexadecimal digit of the digest, as a value from 0
+// to 15.
+func (d *Digest) Nibble(i int) byte {
+	if i%2 == 0 {
+		return d[i/2] >> 4
+	}
+	return d[i/2] & 0x0f
+}
+
+// LeadingZeros tells if the hexadecimal form of the digest starts with n
+// zeros, without encoding it.
+func (d *Digest) LeadingZeros(n int) bool {
+	for i := 0; i < n/2; i++ {
+		if d[i] != 0 {
+			return false
+		}
+	}
+	return n%2 == 0 || d[n/2]>>4 == 0
+}
+
+// Stretch hashes the lowercase hexadecimal form of the digest again rounds
+// times.
+func (d *Digest) Stretch(rounds int) {
+	var text [2 * md5.Size]byte
+	for i := 0; i < rounds; i++ {
+		hex.Encode(text[:], d[:])
+		*d = md5.Sum(text[:])
+	}
+}
+
+// Sum hashes text, then stretches the digest.
+func Sum(text string, stretch int) Digest {
+	d := Digest(md5.Sum([]byte(text)))
+	d.Stretch(stretch)
+	return d
+}
+
+// Hash is the digest of the salt followed by the decimal index.
+func Hash(salt string, index int, stretch int) Digest {
+	buffer := make([]byte, 0, len(salt)+20)
+	return hashInto(buffer, salt, index, stretch)
+}
+
+func hashInto(buffer []byte, salt string, index int, stretch int) Digest {
+	buffer = append(buffer[:0], salt...)
+	buffer = strconv.AppendInt(buffer, int64(index), 10)
+	d := Digest(md5.Sum(buffer))
+	d.Stretch(stretch)
+	return d
+}
+
+type Hit struct {
+	Index  int
+	Digest Digest
+}
+
+const (
+	// Indices hashed by a worker at a time
+	BATCH = 256
+	// Batches computed ahead of the consumer by each worker
+	AHEAD = 4
+)
+
+type Options struct {
+	Salt  string
+	Start int
+	// Extra rounds hashing the hexadecimal form of the digest
+	Stretch int
+	// Only the digests accepted are delivered, all of them when nil. It is
+	// called concurrently by the workers.
+	Filter func(d *Digest) bool
+	// 0 means one per CPU
+	Workers int
+	// 0 means BATCH
+	Batch int
+	// Batches computed but not delivered yet, 0 means AHEAD per worker. The
+	// workers wait when the consumer falls behind.
+	Ahead int
+}
+
+type batch struct {
+	number int
+	hits   []Hit
+}
+
+// Mine hashes salt+index for every index from Start on in parallel and
+// delivers the hits in increasing index order. The channel is closed once
+// ctx is done, which is the only way to stop mining.
+func Mine(ctx context.Context, o Options) <-chan Hit {
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	size := o.Batch
+	if size <= 0 {
+		size = BATCH
+	}
+	ahead := o.Ahead
+	if ahead <= 0 {
+		ahead = AHEAD * workers
+	}
+
+	out := make(chan Hit, size)
+	jobs := make(chan int)
+	results := make(chan batch, workers)
+	// A token per batch in flight, returned once the batch is delivered
+	tokens := make(chan struct{}, ahead)
+
+	go func() {
+		defer close(jobs)
+		for number := 0; ; number++ {
+			select {
+			case tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- number:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for w := 0; w < workers; w++ {
+		go func() {
+			buffer := make([]byte, 0, len(o.Salt)+20)
+			for number := range jobs {
+				var hits []Hit
+				first := o.Start + number*size
+				for index := first; index < first+size; index++ {
+					d := hashInto(buffer, o.Salt, index, o.Stretch)
+					if o.Filter == nil || o.Filter(&d) {
+						hits = append(hits, Hit{Index: index, Digest: d})
+					}
+				}
+				select {
+				case results <- batch{number: number, hits: hits}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(out)
+		pending := make(map[int][]Hit)
+		next := 0
+		for {
+			select {
+			case b := <-results:
+				pending[b.number] = b.hits
+			case <-ctx.Done():
+				return
+			}
+			for {
+				hits, found := pending[next]
+				if !found {
+					break
+				}
+				for _, hit := range hits {
+					select {
+					case out <- hit:
+					case <-ctx.Done():
+						return
+					}
+				}
+				delete(pending, next)
+				<-tokens
+				next++
+			}
+		}
+	}()
+	return out
+}
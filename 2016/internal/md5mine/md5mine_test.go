@@ -0,0 +1,111 @@
+package md5mine
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		salt     string
+		index    int
+		stretch  int
+		expected string
+	}{
+		{salt: "abc", index: 0, stretch: 0, expected: "577571be4de9dcce85a041ba0410f29f"},
+		{salt: "abc", index: 0, stretch: 1, expected: "eec80a0c92dc8a0777c619d9bb51e910"},
+		{salt: "abc", index: 0, stretch: 2016, expected: "a107ff634856bb300138cac6568c0f24"},
+		{salt: "abc", index: 3231929, stretch: 0, expected: "00000155f8105dff7f56ee10fa9b9abd"},
+	}
+
+	for _, test := range tests {
+		result := Hash(test.salt, test.index, test.stretch)
+		if result.Hex() != test.expected {
+			t.Errorf("Hash(%v, %v, %v) = %v; want = %v", test.salt, test.index, test.stretch, result.Hex(), test.expected)
+		}
+	}
+}
+
+func TestNibbles(t *testing.T) {
+	for _, text := range []string{"abc3231929", "abc5017308", "abc0", "hijkl", "ihgpwlah"} {
+		d := Sum(text, 0)
+		hex := d.Hex()
+		for i := 0; i < len(hex); i++ {
+			if expected := strings.IndexByte("0123456789abcdef", hex[i]); int(d.Nibble(i)) != expected {
+				t.Errorf("Sum(%s).Nibble(%d) = %d, want %d", text, i, d.Nibble(i), expected)
+			}
+		}
+		for n := 0; n <= 8; n++ {
+			expected := strings.HasPrefix(hex, strings.Repeat("0", n))
+			if result := d.LeadingZeros(n); result != expected {
+				t.Errorf("Sum(%s).LeadingZeros(%d) = %v, want %v", text, n, result, expected)
+			}
+		}
+	}
+}
+
+func TestMineOrdered(t *testing.T) {
+	filter := func(d *Digest) bool { return d.Nibble(0) == 0 && d.Nibble(1) == 0 }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hits := Mine(ctx, Options{Salt: "abc", Start: 7, Filter: filter, Workers: 4, Batch: 10})
+	index := 7
+	for n := 0; n < 50; n++ {
+		hit := <-hits
+		for ; index < hit.Index; index++ {
+			if d := Hash("abc", index, 0); filter(&d) {
+				t.Fatalf("Mine() skipped index %d", index)
+			}
+		}
+		if d := Hash("abc", index, 0); hit.Index != index || hit.Digest != d || !filter(&d) {
+			t.Fatalf("Mine() = %d %s, want %d %s", hit.Index, hit.Digest.Hex(), index, d.Hex())
+		}
+		index++
+	}
+}
+
+func TestMineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var hashed atomic.Int64
+	filter := func(d *Digest) bool {
+		hashed.Add(1)
+		return true
+	}
+	hits := Mine(ctx, Options{Salt: "abc", Filter: filter, Workers: 2, Batch: 10, Ahead: 3})
+	<-hits
+	// The workers stop once the batches ahead wait for the consumer
+	time.Sleep(50 * time.Millisecond)
+	if n := hashed.Load(); n > 10*(3+1) {
+		t.Errorf("Mine() hashed %d indices while waiting, want at most %d", n, 10*(3+1))
+	}
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, open := <-hits:
+			if !open {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Mine() did not close the channel after cancel")
+		}
+	}
+}
+
+func BenchmarkMine(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hits := Mine(ctx, Options{Salt: "abc"})
+	for i := 0; i < b.N; i++ {
+		<-hits
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Hash("abc", i, 0)
+	}
+}
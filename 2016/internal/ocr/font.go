@@ -0,0 +1,152 @@
+package ocr
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Glyph struct {
+	Letter rune
+	Bitmap Bitmap
+}
+
+type Font struct {
+	Name   string
+	Height int
+	// Sorted from the widest to the narrowest glyph
+	Glyphs []Glyph
+}
+
+// ParseFont reads glyphs written as a "  X" header line followed by the
+// rows of the letter. A letter may appear more than once to accept variants.
+func ParseFont(name, text string) (Font, error) {
+	var f Font
+	f.Name = name
+	var letter rune
+	var rows []string
+	flush := func() error {
+		if letter == 0 {
+			return nil
+		}
+		b, err := ParseBitmap(strings.Join(rows, "\n"))
+		if err != nil {
+			return fmt.Errorf("font %s, letter %c: %w", name, letter, err)
+		}
+		if f.Height == 0 {
+			f.Height = b.rows
+		} else if f.Height != b.rows {
+			return fmt.Errorf("font %s, letter %c: has %d rows, wants %d", name, letter, b.rows, f.Height)
+		}
+		b = b.Trim()
+		if b.rows != f.Height {
+			return fmt.Errorf("font %s, letter %c: must use the first and last rows", name, letter)
+		}
+		f.Glyphs = append(f.Glyphs, Glyph{Letter: letter, Bitmap: b})
+		return nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if strings.ContainsAny(line, "#.") {
+			rows = append(rows, line)
+			continue
+		}
+		if err := flush(); err != nil {
+			return f, err
+		}
+		letter = []rune(line)[0]
+		rows = rows[:0]
+	}
+	if err := flush(); err != nil {
+		return f, err
+	}
+	slices.SortStableFunc(f.Glyphs, func(a, b Glyph) int {
+		return cmp.Compare(b.Bitmap.cols, a.Bitmap.cols)
+	})
+	return f, nil
+}
+
+func MustParseFont(name, text string) Font {
+	f, err := ParseFont(name, text)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (f *Font) matches(b Bitmap, g Glyph, left int) bool {
+	if left+g.Bitmap.cols > b.cols {
+		return false
+	}
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < g.Bitmap.cols; x++ {
+			if g.Bitmap.Get(y, x) != b.Get(y, left+x) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Read scans the picture from left to right, skipping any amount of dark
+// columns between letters. Letters may touch each other, the widest glyph
+// that matches wins. Unrecognized shapes extend to the next dark column and
+// are reported through an *UnknownGlyphsError.
+func (f *Font) Read(p Picture) (string, error) {
+	b := FromPicture(p)
+	top := 0
+	for top < b.rows && b.emptyRow(top) {
+		top++
+	}
+	if top == b.rows {
+		return "", nil
+	}
+	bottom := b.rows
+	for b.emptyRow(bottom - 1) {
+		bottom--
+	}
+	if bottom-top != f.Height {
+		return "", fmt.Errorf("ocr: text is %d rows high, font %s expects %d", bottom-top, f.Name, f.Height)
+	}
+	b = b.Crop(top, 0, f.Height, b.cols)
+
+	var text strings.Builder
+	var unknown []UnknownGlyph
+	for x := 0; x < b.cols; {
+		if b.emptyCol(x) {
+			x++
+			continue
+		}
+		found := false
+		for _, g := range f.Glyphs {
+			if f.matches(b, g, x) {
+				text.WriteRune(g.Letter)
+				x += g.Bitmap.cols
+				found = true
+				break
+			}
+		}
+		if !found {
+			end := x + 1
+			for end < b.cols && !b.emptyCol(end) {
+				end++
+			}
+			unknown = append(unknown, UnknownGlyph{Col: x, Bitmap: b.Crop(0, x, f.Height, end-x)})
+			text.WriteRune(UNKNOWN)
+			x = end
+		}
+	}
+	if len(unknown) > 0 {
+		return text.String(), &UnknownGlyphsError{Text: text.String(), Glyphs: unknown}
+	}
+	return text.String(), nil
+}
+
+var Font6 = MustParseFont("6", font6)
+var Font10 = MustParseFont("10", font10)
+
+var Fonts = []*Font{&Font6, &Font10}
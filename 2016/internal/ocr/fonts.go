@@ -0,0 +1,399 @@
+package ocr
+
+// Letters as displayed by the puzzles with a 6 rows screen, including the
+// variants that differ between years.
+var font6 = `
+  A
+..#..
+.#.#.
+#...#
+#####
+#...#
+#...#
+  A
+.##.
+#..#
+#..#
+####
+#..#
+#..#
+  B
+###.
+#..#
+###.
+#..#
+#..#
+###.
+  C
+.##.
+#..#
+#...
+#...
+#..#
+.##.
+  D
+##..
+#.#.
+#..#
+#..#
+#.#.
+##..
+  E
+####
+#...
+###.
+#...
+#...
+####
+  F
+####
+#...
+###.
+#...
+#...
+#...
+  G
+.##.
+#..#
+#...
+#.##
+#..#
+.##.
+  G
+.##.
+#..#
+#...
+#.##
+#..#
+.###
+  H
+#..#
+#..#
+####
+#..#
+#..#
+#..#
+  I
+###
+.#.
+.#.
+.#.
+.#.
+###
+  J
+...#
+...#
+...#
+#..#
+#..#
+.##.
+  J
+..##
+...#
+...#
+...#
+#..#
+.##.
+  K
+#..#
+#.#.
+##..
+#.#.
+#.#.
+#..#
+  L
+#...
+#...
+#...
+#...
+#...
+####
+  M
+#...#
+##.##
+#.#.#
+#...#
+#...#
+#...#
+  N
+#...#
+##..#
+#.#.#
+#.#.#
+#..##
+#...#
+  O
+.##.
+#..#
+#..#
+#..#
+#..#
+.##.
+  P
+###.
+#..#
+###.
+#...
+#...
+#...
+  P
+###.
+#..#
+#..#
+###.
+#...
+#...
+  Q
+.##..
+#..#.
+#..#.
+#..#.
+#..#.
+.##.#
+  R
+##..
+#.#.
+#.#.
+##..
+#.#.
+#..#
+  R
+###.
+#..#
+#..#
+###.
+#.#.
+#..#
+  S
+.###
+#...
+#...
+.##.
+...#
+###.
+  T
+#####
+..#..
+..#..
+..#..
+..#..
+..#..
+  U
+#..#
+#..#
+#..#
+#..#
+#..#
+.##.
+  V
+#...#
+#...#
+#...#
+#...#
+.#.#.
+..#..
+  W
+#...#
+#...#
+#...#
+#.#.#
+##.##
+#...#
+  X
+#...#
+.#.#.
+..#..
+..#..
+.#.#.
+#...#
+  Y
+#...#
+#...#
+.#.#.
+..#..
+..#..
+..#..
+  Z
+#####
+...#.
+..#..
+.#...
+#....
+#####
+  Z
+####
+...#
+..#.
+.#..
+#...
+####
+`
+
+// Letters as displayed by the puzzles with a 10 rows sky of points.
+var font10 = `
+  A
+..##..
+.#..#.
+#....#
+#....#
+#....#
+######
+#....#
+#....#
+#....#
+#....#
+  B
+#####.
+#....#
+#....#
+#....#
+#####.
+#....#
+#....#
+#....#
+#....#
+#####.
+  C
+.####.
+#....#
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#....#
+.####.
+  E
+######
+#.....
+#.....
+#.....
+#####.
+#.....
+#.....
+#.....
+#.....
+######
+  F
+######
+#.....
+#.....
+#.....
+#####.
+#.....
+#.....
+#.....
+#.....
+#.....
+  G
+.####.
+#....#
+#.....
+#.....
+#.....
+#..###
+#....#
+#....#
+#...##
+.###.#
+  H
+#....#
+#....#
+#....#
+#....#
+######
+#....#
+#....#
+#....#
+#....#
+#....#
+  J
+...###
+....#.
+....#.
+....#.
+....#.
+....#.
+....#.
+#...#.
+#...#.
+.###..
+  K
+#....#
+#...#.
+#..#..
+#.#...
+##....
+##....
+#.#...
+#..#..
+#...#.
+#....#
+  L
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+######
+  N
+#....#
+##...#
+##...#
+#.#..#
+#.#..#
+#..#.#
+#..#.#
+#...##
+#...##
+#....#
+  P
+#####.
+#....#
+#....#
+#....#
+#####.
+#.....
+#.....
+#.....
+#.....
+#.....
+  R
+#####.
+#....#
+#....#
+#....#
+#####.
+#..#..
+#...#.
+#...#.
+#....#
+#....#
+  X
+#....#
+#....#
+.#..#.
+.#..#.
+..##..
+..##..
+.#..#.
+.#..#.
+#....#
+#....#
+  Z
+######
+.....#
+.....#
+....#.
+...#..
+..#...
+.#....
+#.....
+#.....
+######
+`
@@ -0,0 +1,174 @@
+package ocr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UNKNOWN is written in place of every glyph that no font letter matches.
+const UNKNOWN = '?'
+
+type Picture interface {
+	Rows() int
+	Cols() int
+	Get(y, x int) bool
+}
+
+type Bitmap struct {
+	bits []bool
+	rows int
+	cols int
+}
+
+func NewBitmap(rows, cols int) Bitmap {
+	return Bitmap{rows: rows, cols: cols, bits: make([]bool, rows*cols)}
+}
+
+func FromPicture(p Picture) Bitmap {
+	b := NewBitmap(p.Rows(), p.Cols())
+	for y := 0; y < b.rows; y++ {
+		for x := 0; x < b.cols; x++ {
+			b.Set(y, x, p.Get(y, x))
+		}
+	}
+	return b
+}
+
+// ParseBitmap reads a picture where '#', '*' or '█' are lit cells and '.' or
+// ' ' are dark ones. Short lines are padded with dark cells.
+func ParseBitmap(text string) (Bitmap, error) {
+	lines := strings.Split(strings.Trim(text, "\n"), "\n")
+	cols := 0
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+		cols = max(cols, len([]rune(lines[i])))
+	}
+	b := NewBitmap(len(lines), cols)
+	for y, line := range lines {
+		for x, c := range []rune(line) {
+			switch c {
+			case '#', '*', '█':
+				b.Set(y, x, true)
+			case '.', ' ':
+			default:
+				return b, fmt.Errorf("invalid pixel %q at line %d column %d", c, y+1, x+1)
+			}
+		}
+	}
+	return b, nil
+}
+
+func (b Bitmap) Rows() int {
+	return b.rows
+}
+
+func (b Bitmap) Cols() int {
+	return b.cols
+}
+
+func (b Bitmap) Get(y, x int) bool {
+	return b.bits[y*b.cols+x]
+}
+
+func (b *Bitmap) Set(y, x int, lit bool) {
+	b.bits[y*b.cols+x] = lit
+}
+
+func (b Bitmap) String() string {
+	var s strings.Builder
+	for y := 0; y < b.rows; y++ {
+		for x := 0; x < b.cols; x++ {
+			if b.Get(y, x) {
+				s.WriteByte('#')
+			} else {
+				s.WriteByte('.')
+			}
+		}
+		s.WriteByte('\n')
+	}
+	return s.String()
+}
+
+func (b Bitmap) Crop(top, left, rows, cols int) Bitmap {
+	r := NewBitmap(rows, cols)
+	for y := 0; y < rows; y++ {
+		copy(r.bits[y*cols:(y+1)*cols], b.bits[(top+y)*b.cols+left:(top+y)*b.cols+left+cols])
+	}
+	return r
+}
+
+func (b Bitmap) emptyRow(y int) bool {
+	return !slices.Contains(b.bits[y*b.cols:(y+1)*b.cols], true)
+}
+
+func (b Bitmap) emptyCol(x int) bool {
+	for y := 0; y < b.rows; y++ {
+		if b.Get(y, x) {
+			return false
+		}
+	}
+	return true
+}
+
+// Trim removes the dark rows and columns around the lit cells.
+func (b Bitmap) Trim() Bitmap {
+	top, bottom := 0, b.rows
+	for top < bottom && b.emptyRow(top) {
+		top++
+	}
+	for bottom > top && b.emptyRow(bottom-1) {
+		bottom--
+	}
+	left, right := 0, b.cols
+	for left < right && b.emptyCol(left) {
+		left++
+	}
+	for right > left && b.emptyCol(right-1) {
+		right--
+	}
+	if top == bottom || left == right {
+		return NewBitmap(0, 0)
+	}
+	return b.Crop(top, left, bottom-top, right-left)
+}
+
+type UnknownGlyph struct {
+	Col    int
+	Bitmap Bitmap
+}
+
+type UnknownGlyphsError struct {
+	Text   string
+	Glyphs []UnknownGlyph
+}
+
+func (e *UnknownGlyphsError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ocr: %d unknown glyph(s) reading %q", len(e.Glyphs), e.Text)
+	for _, g := range e.Glyphs {
+		fmt.Fprintf(&b, "\nat column %d:\n%s", g.Col, strings.TrimSuffix(g.Bitmap.String(), "\n"))
+	}
+	return b.String()
+}
+
+// Read recognizes the text of the picture using the font that matches the
+// height of its lit area.
+func Read(p Picture) (string, error) {
+	b := FromPicture(p)
+	height := b.Trim().rows
+	for _, f := range Fonts {
+		if f.Height == height {
+			return f.Read(b)
+		}
+	}
+	return "", fmt.Errorf("ocr: no font with height %d", height)
+}
+
+func ReadString(text string) (string, error) {
+	b, err := ParseBitmap(text)
+	if err != nil {
+		return "", err
+	}
+	return Read(b)
+}
@@ -0,0 +1,95 @@
+package ocr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReadString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			// Day 08 screen, Y and F touch each other
+			input: `
+ **  **** *    **** *     **  *   *****  **   *** 
+*  * *    *    *    *    *  * *   **    *  * *    
+*    ***  *    ***  *    *  *  * * ***  *    *    
+*    *    *    *    *    *  *   *  *    *     **  
+*  * *    *    *    *    *  *   *  *    *  *    * 
+ **  *    **** **** ****  **    *  *     **  ***  `,
+			expected: "CFLELOYFCS",
+		},
+		{
+			// Irregular spacing and blank rows around the text
+			input: `
+.................
+.##.......#..#...
+#..#......#..#...
+#..#......####...
+####......#..#...
+#..#......#..#...
+#..#......#..#...
+.................`,
+			expected: "AH",
+		},
+		{
+			input: `
+#....#..######...#####.
+#....#..#........#....#
+.#..#...#........#....#
+.#..#...#........#....#
+..##....#####....#####.
+..##....#........#.....
+.#..#...#........#.....
+.#..#...#........#.....
+#....#..#........#.....
+#....#..######...#.....`,
+			expected: "XEP",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := ReadString(test.input)
+		if err != nil {
+			t.Errorf("ReadString(%v) = error '%v', wants %v", test.input, err, test.expected)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("ReadString(%v) = %v, wants %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestReadUnknownGlyph(t *testing.T) {
+	input := `
+.##...#.#
+#..#..###
+#.....#.#
+#.....###
+#..#..#.#
+.##...#.#`
+
+	result, err := ReadString(input)
+	if result != "C?" {
+		t.Errorf("ReadString(%v) = %v, wants %v", input, result, "C?")
+	}
+	var unknown *UnknownGlyphsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("ReadString(%v) = error '%v', wants *UnknownGlyphsError", input, err)
+	}
+	if len(unknown.Glyphs) != 1 || unknown.Glyphs[0].Col != 6 {
+		t.Fatalf("UnknownGlyphsError.Glyphs = %v, wants one glyph at column 6", unknown.Glyphs)
+	}
+	expected := "#.#\n###\n#.#\n###\n#.#\n#.#\n"
+	if bitmap := unknown.Glyphs[0].Bitmap.String(); bitmap != expected {
+		t.Errorf("UnknownGlyph.Bitmap = %q, wants %q", bitmap, expected)
+	}
+}
+
+func TestReadNoFont(t *testing.T) {
+	if _, err := ReadString("#\n#\n#"); err == nil {
+		t.Errorf("ReadString() with 3 rows = nil error, wants an error")
+	}
+}
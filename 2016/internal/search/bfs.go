@@ -0,0 +1,109 @@
+package search
+
+// BFS finds the path with the fewest moves, the costs given by Neighbors are
+// ignored.
+func BFS[S any, K comparable](p Problem[S, K]) Result[S] {
+	var r Result[S]
+	visited := make(map[K]visit[S, K])
+	startKey := p.Key(p.Start)
+	visited[startKey] = visit[S, K]{state: p.Start, root: true}
+	queue := []K{startKey}
+	r.Stats.enqueue(len(queue))
+	for head := 0; head < len(queue); head++ {
+		key := queue[head]
+		curr := visited[key]
+		if p.Goal(curr.state) {
+			r.Found = true
+			r.Cost = curr.cost
+			r.Path = buildPath(visited, key)
+			return r
+		}
+		r.Stats.Expanded++
+		p.Neighbors(curr.state, func(next S, _ int) {
+			nextKey := p.Key(next)
+			if _, found := visited[nextKey]; found {
+				return
+			}
+			visited[nextKey] = visit[S, K]{state: next, parent: key, cost: curr.cost + 1}
+			queue = append(queue, nextKey)
+			r.Stats.enqueue(len(queue) - head - 1)
+		})
+	}
+	return r
+}
+
+// Walk visits every state reachable from Start in breadth first order with
+// its number of moves, until visit returns false. Goal is not used.
+func Walk[S any, K comparable](p Problem[S, K], visit func(s S, moves int) bool) {
+	type item struct {
+		state S
+		moves int
+	}
+	seen := map[K]bool{p.Key(p.Start): true}
+	queue := []item{{state: p.Start}}
+	for head := 0; head < len(queue); head++ {
+		curr := queue[head]
+		if !visit(curr.state, curr.moves) {
+			return
+		}
+		p.Neighbors(curr.state, func(next S, _ int) {
+			nextKey := p.Key(next)
+			if seen[nextKey] {
+				return
+			}
+			seen[nextKey] = true
+			queue = append(queue, item{state: next, moves: curr.moves + 1})
+		})
+		// Drop the visited part of the queue now and then
+		if head > 1024 && head > len(queue)/2 {
+			queue = append(queue[:0], queue[head+1:]...)
+			head = -1
+		}
+	}
+}
+
+// Reachable returns the number of moves to every state reachable from Start
+// with at most maxMoves moves, or without limit when maxMoves is negative.
+func Reachable[S any, K comparable](p Problem[S, K], maxMoves int) map[K]int {
+	result := make(map[K]int)
+	neighbors := p.Neighbors
+	p.Neighbors = func(s S, yield func(next S, cost int)) {
+		if maxMoves < 0 || result[p.Key(s)] < maxMoves {
+			neighbors(s, yield)
+		}
+	}
+	Walk(p, func(s S, moves int) bool {
+		result[p.Key(s)] = moves
+		return true
+	})
+	return result
+}
+
+// Distances returns the number of moves to each target in a single breadth
+// first search, -1 for the targets that cannot be reached. The search stops
+// once every target is found, so with an infinite state space every target
+// must be reachable.
+func Distances[S any, K comparable](p Problem[S, K], targets []S) []int {
+	result := make([]int, len(targets))
+	indices := make(map[K][]int)
+	for i, target := range targets {
+		result[i] = -1
+		key := p.Key(target)
+		indices[key] = append(indices[key], i)
+	}
+	left := len(indices)
+	if left == 0 {
+		return result
+	}
+	Walk(p, func(s S, moves int) bool {
+		key := p.Key(s)
+		for _, i := range indices[key] {
+			result[i] = moves
+		}
+		if len(indices[key]) > 0 {
+			left--
+		}
+		return left > 0
+	})
+	return result
+}
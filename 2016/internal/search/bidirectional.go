@@ -0,0 +1,63 @@
+package search
+
+// Bidirectional runs a breadth first search from Start and another one from
+// target, expanding the smaller frontier one level at a time until they
+// meet. Neighbors must be symmetric, as in a maze, and Goal is not used.
+func Bidirectional[S any, K comparable](p Problem[S, K], target S) Result[S] {
+	var r Result[S]
+	startKey, targetKey := p.Key(p.Start), p.Key(target)
+	if startKey == targetKey {
+		r.Found = true
+		r.Path = []S{p.Start}
+		return r
+	}
+	forward := map[K]visit[S, K]{startKey: {state: p.Start, root: true}}
+	backward := map[K]visit[S, K]{targetKey: {state: target, root: true}}
+	frontiers := [2][]K{{startKey}, {targetKey}}
+	r.Stats.Enqueued = 2
+	r.Stats.PeakQueue = 2
+	for len(frontiers[0]) > 0 && len(frontiers[1]) > 0 {
+		side := 0
+		if len(frontiers[1]) < len(frontiers[0]) {
+			side = 1
+		}
+		visited, other := forward, backward
+		if side == 1 {
+			visited, other = backward, forward
+		}
+		var next []K
+		var meet K
+		best := -1
+		for _, key := range frontiers[side] {
+			curr := visited[key]
+			r.Stats.Expanded++
+			p.Neighbors(curr.state, func(n S, _ int) {
+				nextKey := p.Key(n)
+				if _, found := visited[nextKey]; found {
+					return
+				}
+				visited[nextKey] = visit[S, K]{state: n, parent: key, cost: curr.cost + 1}
+				next = append(next, nextKey)
+				r.Stats.enqueue(len(next) + len(frontiers[1-side]))
+				if v, found := other[nextKey]; found && (best < 0 || curr.cost+1+v.cost < best) {
+					best = curr.cost + 1 + v.cost
+					meet = nextKey
+				}
+			})
+		}
+		// The whole level is expanded before stopping, a later state of the
+		// level may meet the other search sooner
+		if best >= 0 {
+			r.Found = true
+			r.Cost = best
+			r.Path = buildPath(forward, meet)
+			back := buildPath(backward, meet)
+			for i := len(back) - 2; i >= 0; i-- {
+				r.Path = append(r.Path, back[i])
+			}
+			return r
+		}
+		frontiers[side] = next
+	}
+	return r
+}
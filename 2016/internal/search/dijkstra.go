@@ -0,0 +1,83 @@
+package search
+
+import "container/heap"
+
+type item[S any, K comparable] struct {
+	state    S
+	key      K
+	cost     int
+	priority int
+}
+
+type priorityQueue[S any, K comparable] []item[S, K]
+
+func (pq priorityQueue[S, K]) Len() int {
+	return len(pq)
+}
+func (pq priorityQueue[S, K]) Less(i, j int) bool {
+	if pq[i].priority == pq[j].priority {
+		// Prefer the states closer to the goal
+		return pq[i].cost > pq[j].cost
+	}
+	return pq[i].priority < pq[j].priority
+}
+func (pq priorityQueue[S, K]) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+}
+func (pq *priorityQueue[S, K]) Push(x any) {
+	*pq = append(*pq, x.(item[S, K]))
+}
+func (pq *priorityQueue[S, K]) Pop() any {
+	old := *pq
+	n := len(old)
+	it := old[n-1]
+	*pq = old[0 : n-1]
+	return it
+}
+
+// Dijkstra finds the path with the lowest total cost, costs must not be
+// negative. The heuristic is not used.
+func Dijkstra[S any, K comparable](p Problem[S, K]) Result[S] {
+	p.Heuristic = nil
+	return AStar(p)
+}
+
+// AStar explores the states by cost plus heuristic, with an admissible
+// heuristic the first goal taken from the frontier is the best one. A state
+// already expanded is reopened when a cheaper path reaches it, which only
+// happens with inconsistent heuristics.
+func AStar[S any, K comparable](p Problem[S, K]) Result[S] {
+	var r Result[S]
+	visited := make(map[K]visit[S, K])
+	closed := make(map[K]bool)
+	startKey := p.Key(p.Start)
+	visited[startKey] = visit[S, K]{state: p.Start, root: true}
+	pq := priorityQueue[S, K]{{state: p.Start, key: startKey, priority: p.heuristic(p.Start)}}
+	r.Stats.enqueue(len(pq))
+	for len(pq) > 0 {
+		curr := heap.Pop(&pq).(item[S, K])
+		if closed[curr.key] || curr.cost > visited[curr.key].cost {
+			continue
+		}
+		if p.Goal(curr.state) {
+			r.Found = true
+			r.Cost = curr.cost
+			r.Path = buildPath(visited, curr.key)
+			return r
+		}
+		closed[curr.key] = true
+		r.Stats.Expanded++
+		p.Neighbors(curr.state, func(next S, cost int) {
+			nextKey := p.Key(next)
+			nextCost := curr.cost + cost
+			if v, found := visited[nextKey]; found && v.cost <= nextCost {
+				return
+			}
+			delete(closed, nextKey)
+			visited[nextKey] = visit[S, K]{state: next, parent: curr.key, cost: nextCost}
+			heap.Push(&pq, item[S, K]{state: next, key: nextKey, cost: nextCost, priority: nextCost + p.heuristic(next)})
+			r.Stats.enqueue(len(pq))
+		})
+	}
+	return r
+}
@@ -0,0 +1,63 @@
+package search
+
+import "math"
+
+// IDAStar runs depth first searches bounded by cost plus heuristic, raising
+// the bound each time. It only keeps the current path in memory, at the price
+// of expanding states again on every iteration.
+func IDAStar[S any, K comparable](p Problem[S, K]) Result[S] {
+	var r Result[S]
+	path := []S{p.Start}
+	onPath := map[K]bool{p.Key(p.Start): true}
+	// dfs returns whether a goal was found with its cost, otherwise the lowest
+	// estimate above the bound, math.MaxInt when nothing is left to explore
+	var dfs func(s S, cost, bound int) (bool, int)
+	dfs = func(s S, cost, bound int) (bool, int) {
+		estimate := cost + p.heuristic(s)
+		if estimate > bound {
+			return false, estimate
+		}
+		if p.Goal(s) {
+			return true, cost
+		}
+		r.Stats.Expanded++
+		found := false
+		next := math.MaxInt
+		p.Neighbors(s, func(n S, c int) {
+			if found {
+				return
+			}
+			key := p.Key(n)
+			if onPath[key] {
+				return
+			}
+			onPath[key] = true
+			path = append(path, n)
+			r.Stats.enqueue(len(path))
+			if ok, t := dfs(n, cost+c, bound); ok {
+				found = true
+				next = t
+				return
+			} else {
+				next = min(next, t)
+			}
+			path = path[:len(path)-1]
+			delete(onPath, key)
+		})
+		return found, next
+	}
+	bound := p.heuristic(p.Start)
+	for {
+		found, t := dfs(p.Start, 0, bound)
+		if found {
+			r.Found = true
+			r.Cost = t
+			r.Path = path
+			return r
+		}
+		if t == math.MaxInt {
+			return r
+		}
+		bound = t
+	}
+}
@@ -0,0 +1,67 @@
+package search
+
+// Problem describes a state space, states with the same key are the same
+// state for the search.
+type Problem[S any, K comparable] struct {
+	Start S
+	Key   func(s S) K
+	// Neighbors calls yield for every state reachable from s in one move
+	Neighbors func(s S, yield func(next S, cost int))
+	Goal      func(s S) bool
+	// Heuristic is a lower bound of the cost from s to a goal, nil means 0.
+	// It must never overestimate for A* and IDA* to find the best path.
+	Heuristic func(s S) int
+}
+
+type Stats struct {
+	// States taken from the frontier and expanded
+	Expanded int
+	// States added to the frontier
+	Enqueued int
+	// Largest size reached by the frontier
+	PeakQueue int
+}
+
+func (s *Stats) enqueue(size int) {
+	s.Enqueued++
+	s.PeakQueue = max(s.PeakQueue, size)
+}
+
+type Result[S any] struct {
+	Found bool
+	Cost  int
+	// The states from Start to the goal, both included
+	Path  []S
+	Stats Stats
+}
+
+func (p *Problem[S, K]) heuristic(s S) int {
+	if p.Heuristic == nil {
+		return 0
+	}
+	return p.Heuristic(s)
+}
+
+// The best known way to reach a state
+type visit[S any, K comparable] struct {
+	state  S
+	parent K
+	root   bool
+	cost   int
+}
+
+func buildPath[S any, K comparable](visited map[K]visit[S, K], key K) []S {
+	var path []S
+	for {
+		v := visited[key]
+		path = append(path, v.state)
+		if v.root {
+			break
+		}
+		key = v.parent
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
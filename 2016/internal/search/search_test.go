@@ -0,0 +1,216 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+type point struct {
+	x, y int
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func mazeProblem(maze string, goal point) Problem[point, point] {
+	rows := strings.Split(strings.TrimSpace(maze), "\n")
+	return Problem[point, point]{
+		Start: point{x: 0, y: 0},
+		Key:   func(p point) point { return p },
+		Neighbors: func(p point, yield func(point, int)) {
+			for _, d := range []point{{1, 0}, {0, 1}, {-1, 0}, {0, -1}} {
+				n := point{x: p.x + d.x, y: p.y + d.y}
+				if n.y >= 0 && n.y < len(rows) && n.x >= 0 && n.x < len(rows[n.y]) && rows[n.y][n.x] != '#' {
+					yield(n, 1)
+				}
+			}
+		},
+		Goal:      func(p point) bool { return p == goal },
+		Heuristic: func(p point) int { return abs(p.x-goal.x) + abs(p.y-goal.y) },
+	}
+}
+
+const maze = `
+..#.......
+..#.####..
+..#....#..
+.####..#..
+......##..
+.#.#......`
+
+func checkPath(t *testing.T, name string, p Problem[point, point], r Result[point]) {
+	if len(r.Path) != r.Cost+1 || r.Path[0] != p.Start || !p.Goal(r.Path[len(r.Path)-1]) {
+		t.Errorf("%s path = %v, want %d moves from %v to the goal", name, r.Path, r.Cost, p.Start)
+		return
+	}
+	for i := 1; i < len(r.Path); i++ {
+		a, b := r.Path[i-1], r.Path[i]
+		if abs(a.x-b.x)+abs(a.y-b.y) != 1 {
+			t.Errorf("%s path = %v, %v -> %v is not a move", name, r.Path, a, b)
+		}
+	}
+}
+
+func TestAlgorithmsAgree(t *testing.T) {
+	tests := []struct {
+		goal     point
+		expected int
+		found    bool
+	}{
+		{goal: point{x: 9, y: 0}, expected: 19, found: true},
+		{goal: point{x: 4, y: 2}, expected: 12, found: true},
+		{goal: point{x: 0, y: 0}, expected: 0, found: true},
+		{goal: point{x: 2, y: 0}, expected: 0, found: false},
+	}
+
+	algorithms := []struct {
+		name string
+		run  func(Problem[point, point]) Result[point]
+	}{
+		{name: "BFS", run: BFS[point, point]},
+		{name: "Dijkstra", run: Dijkstra[point, point]},
+		{name: "AStar", run: AStar[point, point]},
+		{name: "IDAStar", run: IDAStar[point, point]},
+	}
+	for _, test := range tests {
+		p := mazeProblem(maze, test.goal)
+		for _, algorithm := range algorithms {
+			r := algorithm.run(p)
+			if r.Found != test.found || r.Cost != test.expected {
+				t.Errorf("%s(%v) = %v %d, want %v %d", algorithm.name, test.goal, r.Found, r.Cost, test.found, test.expected)
+				continue
+			}
+			if r.Found {
+				checkPath(t, algorithm.name, p, r)
+			}
+		}
+	}
+}
+
+func TestAStarExpandsLess(t *testing.T) {
+	p := mazeProblem(maze, point{x: 9, y: 0})
+	dijkstra := Dijkstra(p)
+	astar := AStar(p)
+	if astar.Stats.Expanded >= dijkstra.Stats.Expanded {
+		t.Errorf("AStar expanded %d states, Dijkstra %d, want fewer", astar.Stats.Expanded, dijkstra.Stats.Expanded)
+	}
+}
+
+func TestDijkstraWeighted(t *testing.T) {
+	// 0 -> 1 -> 3 costs 2, 0 -> 3 costs 5, 0 -> 2 -> 3 costs 3
+	edges := map[int][][2]int{
+		0: {{3, 5}, {1, 1}, {2, 1}},
+		1: {{3, 1}},
+		2: {{3, 2}},
+	}
+	p := Problem[int, int]{
+		Start: 0,
+		Key:   func(s int) int { return s },
+		Neighbors: func(s int, yield func(int, int)) {
+			for _, e := range edges[s] {
+				yield(e[0], e[1])
+			}
+		},
+		Goal: func(s int) bool { return s == 3 },
+	}
+	r := Dijkstra(p)
+	if !r.Found || r.Cost != 2 || len(r.Path) != 3 || r.Path[1] != 1 {
+		t.Errorf("Dijkstra() = %v %d %v, want true 2 [0 1 3]", r.Found, r.Cost, r.Path)
+	}
+	if r := BFS(p); r.Cost != 1 {
+		t.Errorf("BFS() = %d moves, want 1", r.Cost)
+	}
+}
+
+func TestReachable(t *testing.T) {
+	p := mazeProblem(maze, point{x: -1, y: -1})
+	tests := []struct {
+		maxMoves int
+		expected int
+	}{
+		{maxMoves: 0, expected: 1},
+		{maxMoves: 2, expected: 5},
+		{maxMoves: -1, expected: 43},
+	}
+
+	for _, test := range tests {
+		result := Reachable(p, test.maxMoves)
+		if len(result) != test.expected {
+			t.Errorf("Reachable(%d) = %d states, want %d", test.maxMoves, len(result), test.expected)
+		}
+		for s, moves := range result {
+			if test.maxMoves >= 0 && moves > test.maxMoves {
+				t.Errorf("Reachable(%d)[%v] = %d, more than allowed", test.maxMoves, s, moves)
+			}
+		}
+	}
+}
+
+func TestBidirectional(t *testing.T) {
+	rows := strings.Split(strings.TrimSpace(maze), "\n")
+	for y, row := range rows {
+		for x := range row {
+			if row[x] == '#' {
+				// Walls are left by their neighbors but never entered
+				continue
+			}
+			goal := point{x: x, y: y}
+			p := mazeProblem(maze, goal)
+			expected := BFS(p)
+			r := Bidirectional(p, goal)
+			if r.Found != expected.Found || r.Cost != expected.Cost {
+				t.Errorf("Bidirectional(%v) = %v, %d moves; want %v, %d moves", goal, r.Found, r.Cost, expected.Found, expected.Cost)
+				continue
+			}
+			if r.Found {
+				checkPath(t, "Bidirectional", p, r)
+			}
+		}
+	}
+}
+
+func TestDistances(t *testing.T) {
+	p := mazeProblem(maze, point{x: -1, y: -1})
+	targets := []point{{x: 9, y: 0}, {x: 0, y: 0}, {x: 2, y: 0}, {x: 4, y: 2}, {x: 9, y: 0}}
+	expected := []int{19, 0, -1, 12, 19}
+	result := Distances(p, targets)
+	for i := range targets {
+		if result[i] != expected[i] {
+			t.Errorf("Distances()[%v] = %d, want %d", targets[i], result[i], expected[i])
+		}
+	}
+}
+
+func TestAStarInconsistent(t *testing.T) {
+	// The heuristic never overestimates but drops by more than the cost of
+	// S -> B, so C is first expanded through the longer path S -> A -> C
+	edges := map[string][]struct {
+		to   string
+		cost int
+	}{
+		"S": {{to: "A", cost: 1}, {to: "B", cost: 2}},
+		"A": {{to: "C", cost: 3}},
+		"B": {{to: "C", cost: 1}},
+		"C": {{to: "G", cost: 3}},
+	}
+	h := map[string]int{"B": 3}
+	p := Problem[string, string]{
+		Start: "S",
+		Key:   func(s string) string { return s },
+		Neighbors: func(s string, yield func(string, int)) {
+			for _, e := range edges[s] {
+				yield(e.to, e.cost)
+			}
+		},
+		Goal:      func(s string) bool { return s == "G" },
+		Heuristic: func(s string) int { return h[s] },
+	}
+	r := AStar(p)
+	if !r.Found || r.Cost != 6 || strings.Join(r.Path, "") != "SBCG" {
+		t.Errorf("AStar() = %v with cost %d, want SBCG with cost 6", r.Path, r.Cost)
+	}
+}